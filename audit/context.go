@@ -0,0 +1,30 @@
+package audit
+
+import "context"
+
+// SystemActor is recorded for changes that do not come from an HTTP request,
+// e.g. CLI commands and background jobs.
+const SystemActor = "system"
+
+// Metadata identifies who made a change and from where.
+type Metadata struct {
+	Actor      string
+	RequestID  string
+	RemoteAddr string
+}
+
+type metadataKey struct{}
+
+// WithMetadata returns a copy of ctx carrying the audit metadata.
+func WithMetadata(ctx context.Context, metadata Metadata) context.Context {
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// MetadataFrom returns the audit metadata carried by ctx. Contexts without
+// metadata are attributed to SystemActor.
+func MetadataFrom(ctx context.Context) Metadata {
+	if metadata, ok := ctx.Value(metadataKey{}).(Metadata); ok {
+		return metadata
+	}
+	return Metadata{Actor: SystemActor}
+}
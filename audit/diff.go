@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Change is the old and new value of a single field.
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Diff compares the JSON representations of before and after and returns the
+// fields whose values differ. Either side may be nil for creates and deletes,
+// in which case its fields count as null.
+func Diff(before, after interface{}) map[string]Change {
+	beforeFields := fields(before)
+	afterFields := fields(after)
+
+	changes := map[string]Change{}
+	for name, value := range afterFields {
+		if old := beforeFields[name]; !reflect.DeepEqual(old, value) {
+			changes[name] = Change{From: old, To: value}
+		}
+	}
+	for name, old := range beforeFields {
+		if _, ok := afterFields[name]; !ok && old != nil {
+			changes[name] = Change{From: old}
+		}
+	}
+	return changes
+}
+
+func fields(value interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return result
+	}
+	// A nil value marshals to "null", which leaves result empty
+	json.Unmarshal(data, &result)
+	return result
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"VSA_GOGIN_BE/audit"
+	"VSA_GOGIN_BE/config"
+	"VSA_GOGIN_BE/database"
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/metrics"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/services"
+	"VSA_GOGIN_BE/tracing"
+	"VSA_GOGIN_BE/validators"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// command is a subcommand of the binary. Commands with subcommands of their
+// own dispatch to them from run.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, cfg config.Config, args []string) error
+}
+
+var commands = []command{
+	{"serve", "start the HTTP server (default)", runServe},
+	{"migrate", "bring the database schema up to date", runMigrate},
+	{"seed", "load seed datasets into the database", runSeed},
+	{"aircraft", "import or list aircraft", runAircraft},
+	{"voucher", "generate, list or export vouchers", runVoucher},
+	{"cache", "flush or warm the free seat cache", runCache},
+	{"events", "consume the event stream or replay it to a consumer group", runEvents},
+}
+
+// errUsage is returned by commands after printing their usage.
+var errUsage = errors.New("usage")
+
+var progName = filepath.Base(os.Args[0])
+
+// stdout receives command output, keeping it apart from the logs.
+var stdout io.Writer = os.Stdout
+
+func run(args []string) int {
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" || name == "-h" {
+		printUsage()
+		return 0
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage()
+		return 2
+	}
+
+	cfg := config.Load()
+	// Only the server logs to stdout; commands keep it for their output.
+	logOutput := os.Stderr
+	if cmd.name == "serve" {
+		logOutput = os.Stdout
+	}
+	if err := setupLogging(cfg, logOutput); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	validators.Register()
+	models.DefaultTimezone = cfg.DefaultTimezone
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if cmd.name != "serve" {
+		ctx = audit.WithMetadata(ctx, audit.Metadata{Actor: cliActor()})
+	}
+
+	err := cmd.run(ctx, cfg, args)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+		return 2
+	default:
+		slog.Error("command failed", "command", cmd.name, "error", err)
+		return 1
+	}
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", progName)
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command.\n", progName)
+}
+
+// subcommand picks the subcommand named by args[0] from subcommands.
+func subcommand(name string, args []string, subcommands map[string]func([]string) error, usage string) error {
+	if len(args) == 0 || subcommands[args[0]] == nil {
+		fmt.Fprintf(os.Stderr, "Usage: %s %s %s\n", progName, name, usage)
+		return errUsage
+	}
+	return subcommands[args[0]](args[1:])
+}
+
+// newFlagSet returns a flag set that reports parse errors instead of exiting.
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(progName+" "+name, flag.ContinueOnError)
+}
+
+// cliActor is recorded in the audit log for changes made from the command
+// line.
+func cliActor() string {
+	if user := os.Getenv("USER"); user != "" {
+		return "cli:" + user
+	}
+	return "cli"
+}
+
+func setupLogging(cfg config.Config, output io.Writer) error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+	moduleLevels, err := logging.ParseModuleLevels(cfg.LogModuleLevels)
+	if err != nil {
+		return err
+	}
+	logging.Setup(logging.Options{
+		Level:        level,
+		ModuleLevels: moduleLevels,
+		Format:       cfg.LogFormat,
+		RedactFields: cfg.LogRedactFields,
+		Output:       output,
+	})
+	redis.SetLogger(logging.RedisLogger{})
+	return nil
+}
+
+// openDatabase opens the SQLite database with metrics and tracing plugins.
+func openDatabase(cfg config.Config) (*gorm.DB, error) {
+	db, err := database.Open(cfg.DatabasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// Time database statements
+	if err := db.Use(metrics.NewGormPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to instrument database: %w", err)
+	}
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to instrument database: %w", err)
+	}
+	return db, nil
+}
+
+// openMigratedDatabase opens the database and refuses to work on a schema
+// that is behind, pointing at the migrate command instead.
+func openMigratedDatabase(ctx context.Context, cfg config.Config) (*gorm.DB, error) {
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.CheckSchema(ctx, db); err != nil {
+		closeDatabase(db)
+		return nil, fmt.Errorf("%w (run the migrate command first)", err)
+	}
+	return db, nil
+}
+
+func closeDatabase(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
+func connectRedis(cfg config.Config) (*redis.Client, error) {
+	rdb, err := config.InitRedis(cfg.RedisAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis at %s: %w", cfg.RedisAddr, err)
+	}
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		rdb.Close()
+		return nil, fmt.Errorf("failed to instrument Redis: %w", err)
+	}
+	return rdb, nil
+}
+
+// appServices holds the services shared by the server and the commands.
+type appServices struct {
+	Audit    *services.AuditService
+	Aircraft *services.AircraftService
+	Voucher  *services.VoucherService
+	Report   *services.ReportService
+	Webhook  *services.WebhookService
+	Outbox   *services.OutboxService
+}
+
+// newServices wires the repositories and services. rdb may be nil for
+// commands that do not touch the voucher cache.
+func newServices(cfg config.Config, db *gorm.DB, rdb *redis.Client) appServices {
+	aircraftRepository := repositories.NewAircraftRepository(db)
+	voucherRepository := repositories.NewVoucherRepository(db)
+	auditService := services.NewAuditService(repositories.NewAuditLogRepository(db))
+	retryPolicy := repositories.DefaultRetryPolicy
+	retryPolicy.MaxAttempts = cfg.TxMaxAttempts
+	transactor := repositories.NewTransactor(db, retryPolicy)
+	webhookService := services.NewWebhookService(repositories.NewWebhookRepository(db), auditService, transactor, cfg.WebhookTimeout, repositories.RetryPolicy{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		BaseDelay:   cfg.WebhookRetryBaseDelay,
+		MaxDelay:    cfg.WebhookRetryMaxDelay,
+	}, cfg.WebhookAllowInsecureURLs)
+	outboxService := services.NewOutboxService(repositories.NewOutboxRepository(db), rdb, cfg.EventStream, int64(cfg.EventStreamMaxLen))
+	events := services.EventPublishers{webhookService, outboxService}
+	return appServices{
+		Audit:    auditService,
+		Aircraft: services.NewAircraftService(aircraftRepository, voucherRepository, auditService, rdb, services.DeletePolicy(cfg.AircraftDeletePolicy), transactor, events),
+		Voucher:  services.NewVoucherService(voucherRepository, aircraftRepository, auditService, rdb, transactor, events),
+		Report:   services.NewReportService(repositories.NewReportRepository(db)),
+		Webhook:  webhookService,
+		Outbox:   outboxService,
+	}
+}
+
+func printJSON(v interface{}) error {
+	return printJSONTo(stdout, v)
+}
+
+func printJSONTo(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"VSA_GOGIN_BE/config"
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/validators"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+func runAircraft(ctx context.Context, cfg config.Config, args []string) error {
+	return subcommand("aircraft", args, map[string]func([]string) error{
+		"import": func(args []string) error { return importAircraft(ctx, cfg, args) },
+		"list":   func(args []string) error { return listAircraft(ctx, cfg, args) },
+	}, "import|list [flags]")
+}
+
+// importAircraft creates the aircraft listed in a JSON or CSV file, skipping
+// those whose type key exists already so imports can be rerun.
+func importAircraft(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("aircraft import")
+	file := flags.String("file", "", "JSON array or CSV file (aircraft_type,num_rows,seats_per_row[,blocked_seats]) to import")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	requests, err := readAircraftFile(*file)
+	if err != nil {
+		return err
+	}
+	for i, req := range requests {
+		if err := binding.Validator.ValidateStruct(req); err != nil {
+			if fields := validators.FieldErrors(err); fields != nil {
+				return fmt.Errorf("aircraft %d: %s %s", i+1, fields[0].Field, fields[0].Message)
+			}
+			return fmt.Errorf("aircraft %d: %w", i+1, err)
+		}
+	}
+
+	db, err := openMigratedDatabase(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+	svc := newServices(cfg, db, nil)
+
+	existing, err := svc.Aircraft.ListAircraft(ctx, true)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool, len(existing))
+	for _, aircraft := range existing {
+		keys[aircraft.AircraftTypeKey] = true
+	}
+
+	var created, skipped int
+	for _, req := range requests {
+		aircraft := req.ToModel()
+		if keys[models.AircraftTypeKeyFor(aircraft.AircraftType)] {
+			skipped++
+			continue
+		}
+		if err := svc.Aircraft.CreateAircraft(ctx, &aircraft); err != nil {
+			return fmt.Errorf("failed to import %s: %w", aircraft.AircraftType, err)
+		}
+		keys[aircraft.AircraftTypeKey] = true
+		created++
+	}
+	fmt.Fprintf(stdout, "imported %d aircraft, skipped %d existing\n", created, skipped)
+	return nil
+}
+
+func readAircraftFile(path string) ([]dto.AircraftRequest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var requests []dto.AircraftRequest
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		requests, err = readAircraftCSV(f)
+	} else {
+		err = json.NewDecoder(f).Decode(&requests)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return requests, nil
+}
+
+func readAircraftCSV(r io.Reader) ([]dto.AircraftRequest, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"aircraft_type", "num_rows", "seats_per_row"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %s", name)
+		}
+	}
+
+	requests := make([]dto.AircraftRequest, 0, len(records)-1)
+	for line, record := range records[1:] {
+		numRows, err := strconv.Atoi(strings.TrimSpace(record[columns["num_rows"]]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid num_rows: %w", line+2, err)
+		}
+		request := dto.AircraftRequest{
+			AircraftType: strings.TrimSpace(record[columns["aircraft_type"]]),
+			NumRows:      numRows,
+			SeatsPerRow:  strings.TrimSpace(record[columns["seats_per_row"]]),
+		}
+		if i, ok := columns["blocked_seats"]; ok {
+			// Seats are separated by spaces or commas within the cell.
+			request.BlockedSeats = strings.FieldsFunc(record[i], func(r rune) bool {
+				return r == ' ' || r == ','
+			})
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+func listAircraft(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("aircraft list")
+	includeDeleted := flags.Bool("include-deleted", false, "include soft-deleted aircraft")
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := openMigratedDatabase(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	aircraft, err := newServices(cfg, db, nil).Aircraft.ListAircraft(ctx, *includeDeleted)
+	if err != nil {
+		return err
+	}
+	responses := dto.NewAircraftResponses(aircraft)
+	if *asJSON {
+		return printJSON(responses)
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tKEY\tROWS\tSEATS PER ROW\tDELETED")
+	for _, a := range responses {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%t\n", a.ID, a.AircraftType, a.AircraftTypeKey, a.NumRows, a.SeatsPerRow, a.DeletedAt != nil)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"VSA_GOGIN_BE/config"
+)
+
+func runCache(ctx context.Context, cfg config.Config, args []string) error {
+	return subcommand("cache", args, map[string]func([]string) error{
+		"flush": func(args []string) error { return flushCache(ctx, cfg, args) },
+		"warm":  func(args []string) error { return warmCache(ctx, cfg, args) },
+	}, "flush|warm")
+}
+
+// flushCache drops every cached free seat list, e.g. after seats were edited
+// in the database by hand.
+func flushCache(ctx context.Context, cfg config.Config, args []string) error {
+	if err := newFlagSet("cache flush").Parse(args); err != nil {
+		return err
+	}
+
+	rdb, err := connectRedis(cfg)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+
+	// The cache does not touch the database.
+	removed, err := newServices(cfg, nil, rdb).Voucher.FlushSeatCache(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "removed %d cached seat lists\n", removed)
+	return nil
+}
+
+// warmCache caches the free seats of upcoming flights ahead of traffic.
+func warmCache(ctx context.Context, cfg config.Config, args []string) error {
+	if err := newFlagSet("cache warm").Parse(args); err != nil {
+		return err
+	}
+
+	db, err := openMigratedDatabase(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+	rdb, err := connectRedis(cfg)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+
+	flights, err := newServices(cfg, db, rdb).Voucher.WarmSeatCache(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "cached free seats for %d flights\n", flights)
+	return nil
+}
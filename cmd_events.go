@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"VSA_GOGIN_BE/config"
+	"VSA_GOGIN_BE/services"
+)
+
+func runEvents(ctx context.Context, cfg config.Config, args []string) error {
+	return subcommand("events", args, map[string]func([]string) error{
+		"consume": func(args []string) error { return consumeEvents(ctx, cfg, args) },
+		"replay":  func(args []string) error { return replayEvents(ctx, cfg, args) },
+	}, "consume|replay [flags]")
+}
+
+// consumeEvents prints the events of the stream as a consumer of a group, one
+// JSON object per line, until interrupted. Entries are acknowledged once
+// printed.
+func consumeEvents(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("events consume")
+	group := flags.String("group", "", "consumer group to read as")
+	hostname, _ := os.Hostname()
+	consumer := flags.String("consumer", hostname, "consumer name, unique within the group")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *group == "" || *consumer == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	rdb, err := connectRedis(cfg)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+
+	encoder := json.NewEncoder(stdout)
+	return services.NewEventConsumer(rdb, cfg.EventStream, *group, *consumer).Consume(ctx, func(ctx context.Context, event services.StreamEvent) error {
+		return encoder.Encode(map[string]interface{}{
+			"stream_id": event.StreamID,
+			"event_id":  event.EventID,
+			"type":      event.Type,
+			"payload":   json.RawMessage(event.Payload),
+		})
+	})
+}
+
+// replayEvents makes a consumer group read the stream again after an offset.
+func replayEvents(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("events replay")
+	group := flags.String("group", "", "consumer group to replay to")
+	from := flags.String("from", "", `stream ID of the last entry the group counts as read; "0" replays the whole stream`)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *group == "" || *from == "" {
+		flags.Usage()
+		return errUsage
+	}
+
+	rdb, err := connectRedis(cfg)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+
+	// Replaying does not touch the database.
+	if err := newServices(cfg, nil, rdb).Outbox.Replay(ctx, *group, *from); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "group %s replays %s after %s\n", *group, cfg.EventStream, *from)
+	return nil
+}
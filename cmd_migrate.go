@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"VSA_GOGIN_BE/config"
+	"VSA_GOGIN_BE/database"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/seed"
+)
+
+func runMigrate(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("migrate")
+	check := flags.Bool("check", false, "only report whether a migration is pending")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	if *check {
+		if err := database.CheckSchema(ctx, db); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, "schema is up to date")
+		return nil
+	}
+
+	if err := database.Migrate(db.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to migrate database: %w", err)
+	}
+	fmt.Fprintln(stdout, "schema migrated")
+	return nil
+}
+
+func runSeed(ctx context.Context, cfg config.Config, args []string) error {
+	if len(args) > 0 && args[0] == "synthetic" {
+		return seedSynthetic(ctx, cfg, args[1:])
+	}
+
+	names := make([]string, 0, len(seed.Datasets))
+	for _, dataset := range seed.Datasets {
+		names = append(names, dataset.Name)
+	}
+
+	flags := newFlagSet("seed")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s seed [flags]\n       %s seed synthetic [flags]\n\nFlags:\n", progName, progName)
+		flags.PrintDefaults()
+	}
+	profile := flags.String("profile", cfg.SeedProfile, "fixture profile: "+strings.Join(seed.Profiles, ", "))
+	dir := flags.String("dir", "", "load <profile>.json from this directory instead of the built-in fixtures")
+	datasets := flags.String("datasets", "", "comma-separated datasets to load: "+strings.Join(names, ", ")+" (default all)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var fsys fs.FS
+	if *dir != "" {
+		fsys = os.DirFS(*dir)
+	}
+	fixtures, err := seed.LoadFixtures(fsys, *profile)
+	if err != nil {
+		return err
+	}
+
+	db, err := openMigratedDatabase(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	var selected []string
+	for _, name := range strings.Split(*datasets, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			selected = append(selected, name)
+		}
+	}
+	if err := seed.Run(db.WithContext(ctx), fixtures, selected...); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "seeded profile %s\n", *profile)
+	return nil
+}
+
+// seedSynthetic generates flights and vouchers for load testing.
+func seedSynthetic(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("seed synthetic")
+	flights := flags.Int("flights", 100, "number of flights to create")
+	vouchers := flags.Int("vouchers", 20, "vouchers per flight")
+	days := flags.Int("days", 30, "days to spread the flights over")
+	start := flags.String("start", "", "first flight date, YYYY-MM-DD (default tomorrow)")
+	randSeed := flags.Int64("rand-seed", 1, "seed for the seat assignment")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts := seed.SyntheticOptions{
+		Flights:           *flights,
+		Days:              *days,
+		VouchersPerFlight: *vouchers,
+		RandSeed:          *randSeed,
+	}
+	if *start == "" {
+		loc, err := models.AirportLocation("")
+		if err != nil {
+			return err
+		}
+		opts.StartDate = models.DateOf(time.Now().In(loc)).AddDays(1)
+	} else {
+		date, err := models.ParseDate(*start)
+		if err != nil {
+			return fmt.Errorf("invalid start date: %w", err)
+		}
+		opts.StartDate = date
+	}
+
+	db, err := openMigratedDatabase(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+
+	result, err := seed.Synthetic(ctx, db, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "created %d flights with %d vouchers\n", result.Flights, result.Vouchers)
+	return nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"VSA_GOGIN_BE/config"
+	"VSA_GOGIN_BE/controllers"
+	"VSA_GOGIN_BE/database"
+	"VSA_GOGIN_BE/jobs"
+	"VSA_GOGIN_BE/lifecycle"
+	"VSA_GOGIN_BE/metrics"
+	"VSA_GOGIN_BE/middleware"
+	"VSA_GOGIN_BE/routes"
+	"VSA_GOGIN_BE/seed"
+	"VSA_GOGIN_BE/services"
+	"VSA_GOGIN_BE/tracing"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+
+	// Swagger
+	_ "VSA_GOGIN_BE/docs"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+func runServe(_ context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("serve")
+	migrate := flags.Bool("migrate", true, "migrate the schema before serving")
+	seedOnStart := flags.Bool("seed", true, "load the fixtures of the SEED_PROFILE profile before serving")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	// Stop the server, workers and connections in order on SIGTERM
+	app := lifecycle.NewManager(cfg.ShutdownTimeout)
+
+	// Create a Gin router; logging and panic recovery are our own
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.RequestLogger(), middleware.Recovery())
+
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	// Trace requests through GORM and Redis
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    cfg.TracingExporter,
+		File:        cfg.TracingFile,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	app.OnShutdown("tracing", shutdownTracing)
+	router.Use(otelgin.Middleware(tracing.ServiceName))
+
+	redisClient, err := connectRedis(cfg)
+	if err != nil {
+		return err
+	}
+	app.OnShutdown("redis", func(context.Context) error { return redisClient.Close() })
+	slog.Info("Connected to Redis")
+
+	// Enable CORS for frontend development
+	router.Use(cors.New(cors.Config{
+		AllowOrigins:     []string{"http://localhost:3000"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Actor", "X-Request-ID", "X-API-Key", "Idempotency-Key", "traceparent", "tracestate"},
+		ExposeHeaders:    []string{"Content-Length", "Idempotent-Replayed", "X-Request-ID", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"},
+		AllowCredentials: true,
+	}))
+
+	// Count requests and their latency per route
+	router.Use(middleware.Metrics())
+
+	// Cancel database and Redis work for requests running past their deadline
+	router.Use(middleware.Timeout(cfg.RequestTimeout))
+
+	// Attribute changes to the calling actor and request in the audit log
+	router.Use(middleware.AuditMetadata())
+
+	// Database connection using SQLite
+	db, err := openDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to access database: %w", err)
+	}
+	app.OnShutdown("database", func(context.Context) error { return sqlDB.Close() })
+	databaseCheck := services.HealthCheck{Name: "database", Check: sqlDB.PingContext}
+
+	// Migrate the schema
+	if *migrate {
+		if err := database.Migrate(db); err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	// Seed default data
+	readiness := []services.HealthCheck{
+		databaseCheck,
+		{Name: "redis", Check: func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }},
+		{Name: "migrations", Check: func(ctx context.Context) error { return database.CheckSchema(ctx, db) }},
+	}
+	if *seedOnStart {
+		fixtures, err := seed.LoadFixtures(nil, cfg.SeedProfile)
+		if err != nil {
+			return err
+		}
+		if err := seed.Run(db, fixtures); err != nil {
+			return err
+		}
+		readiness = append(readiness, services.HealthCheck{Name: "seed", Check: func(ctx context.Context) error { return seed.Check() }})
+	}
+
+	// Initialize repositories and services
+	svc := newServices(cfg, db, redisClient)
+
+	// Report free seats per active flight on every scrape
+	prometheus.MustRegister(metrics.NewFreeSeatsCollector(svc.Voucher.FreeSeatsByFlight))
+
+	// Initialize controllers
+	aircraftController := controllers.NewAircraftController(svc.Aircraft)
+	voucherController := controllers.NewVoucherController(svc.Voucher)
+	auditController := controllers.NewAuditController(svc.Audit)
+	flightController := controllers.NewFlightController(svc.Voucher)
+	reportController := controllers.NewReportController(svc.Report)
+	webhookController := controllers.NewWebhookController(svc.Webhook)
+	eventController := controllers.NewEventController(svc.Outbox)
+	healthController := controllers.NewHealthController(services.NewHealthService(
+		[]services.HealthCheck{databaseCheck},
+		readiness,
+		cfg.HealthCheckTimeout,
+	))
+
+	// Archive vouchers for long-gone flights in the background
+	if cfg.ArchiveRetention > 0 {
+		archiveJob := jobs.NewArchiveJob(svc.Voucher, cfg.ArchiveRetention, cfg.ArchiveInterval)
+		app.Go("archive", archiveJob.Run)
+	}
+
+	// Deliver webhooks for voucher events in the background
+	webhookJob := jobs.NewWebhookJob(svc.Webhook, cfg.WebhookPollInterval)
+	app.Go("webhooks", webhookJob.Run)
+
+	// Relay voucher and aircraft events from the outbox to the event stream
+	if err := svc.Outbox.CreateGroups(context.Background(), cfg.EventConsumerGroups); err != nil {
+		return err
+	}
+	outboxJob := jobs.NewOutboxRelayJob(svc.Outbox, cfg.OutboxRelayInterval, cfg.OutboxRetention)
+	app.Go("outbox", outboxJob.Run)
+
+	// Setup routes
+	routes.SetupAircraftRoutes(router, aircraftController)
+	rateLimit := middleware.RateLimit(
+		middleware.NewFallbackRateLimitStore(middleware.NewRedisRateLimitStore(redisClient), middleware.NewMemoryRateLimitStore()),
+		middleware.RateLimitByIP(cfg.RateLimitPerIP, cfg.RateLimitWindow),
+		middleware.RateLimitByAPIKey(cfg.RateLimitPerAPIKey, cfg.RateLimitWindow),
+		middleware.RateLimitByCrewID(cfg.RateLimitPerCrew, cfg.RateLimitWindow),
+	)
+	idempotency := middleware.Idempotency(middleware.NewRedisIdempotencyStore(redisClient), cfg.IdempotencyTTL)
+	routes.SetupVoucherRoutes(router, voucherController, rateLimit, idempotency)
+	routes.SetupFlightRoutes(router, flightController)
+	routes.SetupReportRoutes(router, reportController)
+	routes.SetupWebhookRoutes(router, webhookController)
+	routes.SetupEventRoutes(router, eventController)
+	routes.SetupAuditRoutes(router, auditController)
+
+	// Prometheus metrics endpoint
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Swagger UI endpoint
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Liveness and readiness probes
+	routes.SetupHealthRoutes(router, healthController)
+
+	// Basic health check endpoint
+	router.GET("/ping", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"message": "pong",
+		})
+	})
+
+	// Start the server and block until it has shut down
+	server := &http.Server{
+		Addr:              cfg.HTTPAddr,
+		Handler:           router,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+	}
+	return app.Run(server)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"VSA_GOGIN_BE/config"
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/validators"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+func runVoucher(ctx context.Context, cfg config.Config, args []string) error {
+	return subcommand("voucher", args, map[string]func([]string) error{
+		"generate": func(args []string) error { return generateVoucher(ctx, cfg, args) },
+		"list":     func(args []string) error { return listVouchers(ctx, cfg, args) },
+		"export":   func(args []string) error { return exportVouchers(ctx, cfg, args) },
+	}, "generate|list|export [flags]")
+}
+
+func generateVoucher(ctx context.Context, cfg config.Config, args []string) error {
+	var req dto.VoucherGenerateRequest
+	flags := newFlagSet("voucher generate")
+	flags.StringVar(&req.CrewName, "crew-name", "", "crew member name")
+	flags.StringVar(&req.CrewID, "crew-id", "", "crew member ID")
+	flags.StringVar(&req.CrewBase, "crew-base", "", "IATA code of the crew member's home base")
+	flags.StringVar(&req.FlightNumber, "flight", "", "flight number, e.g. GA123")
+	flags.StringVar(&req.FlightDate, "date", "", "local departure date (YYYY-MM-DD)")
+	flags.StringVar(&req.DepartureAirport, "airport", "", "IATA code of the departure airport")
+	flags.StringVar(&req.AircraftTypeKey, "aircraft", "", "aircraft type key, e.g. atr_72")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		if fields := validators.FieldErrors(err); fields != nil {
+			return fmt.Errorf("%s %s", fields[0].Field, fields[0].Message)
+		}
+		return err
+	}
+	voucher, err := req.ToModel()
+	if err != nil {
+		return err
+	}
+
+	db, err := openMigratedDatabase(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDatabase(db)
+	rdb, err := connectRedis(cfg)
+	if err != nil {
+		return err
+	}
+	defer rdb.Close()
+
+	seats, err := newServices(cfg, db, rdb).Voucher.GenerateVoucherSeats(ctx, &voucher)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "voucher %d: %s\n", voucher.ID, strings.Join(seats, " "))
+	return nil
+}
+
+func listVouchers(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("voucher list")
+	includeDeleted := flags.Bool("include-deleted", false, "include cancelled vouchers")
+	asJSON := flags.Bool("json", false, "print JSON instead of a table")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	responses, err := loadVouchers(ctx, cfg, *includeDeleted)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(responses)
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCREW ID\tFLIGHT\tDATE\tAIRCRAFT\tSEATS\tSTATUS")
+	for _, v := range responses {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", v.ID, v.CrewID, v.FlightNumber, v.FlightDate, v.AircraftTypeKey,
+			strings.Join(nonEmpty(v.Seat1, v.Seat2, v.Seat3), " "), v.Status)
+	}
+	return w.Flush()
+}
+
+// exportVouchers writes every voucher as CSV or JSON to a file or stdout.
+func exportVouchers(ctx context.Context, cfg config.Config, args []string) error {
+	flags := newFlagSet("voucher export")
+	format := flags.String("format", "csv", "export format: csv or json")
+	output := flags.String("o", "", "output file (default stdout)")
+	includeDeleted := flags.Bool("include-deleted", false, "include cancelled vouchers")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unknown format %q, expected csv or json", *format)
+	}
+
+	responses, err := loadVouchers(ctx, cfg, *includeDeleted)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *format == "json" {
+		return printJSONTo(w, responses)
+	}
+	return writeVouchersCSV(w, responses)
+}
+
+func writeVouchersCSV(w io.Writer, vouchers []dto.VoucherResponse) error {
+	header := []string{"id", "crew_id", "crew_name", "crew_base", "flight_number", "flight_date", "departure_airport", "aircraft_type", "aircraft_type_key", "seat1", "seat2", "seat3", "status", "created_at"}
+	records := make([][]string, 0, len(vouchers))
+	for _, v := range vouchers {
+		records = append(records, []string{
+			strconv.FormatUint(uint64(v.ID), 10),
+			v.CrewID,
+			v.CrewName,
+			v.CrewBase,
+			v.FlightNumber,
+			v.FlightDate.String(),
+			v.DepartureAirport,
+			v.AircraftType,
+			v.AircraftTypeKey,
+			v.Seat1,
+			v.Seat2,
+			v.Seat3,
+			v.Status,
+			v.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return dto.WriteCSV(w, header, records)
+}
+
+func loadVouchers(ctx context.Context, cfg config.Config, includeDeleted bool) ([]dto.VoucherResponse, error) {
+	db, err := openMigratedDatabase(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDatabase(db)
+
+	vouchers, err := newServices(cfg, db, nil).Voucher.ListVouchers(ctx, includeDeleted)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewVoucherResponses(vouchers), nil
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// DatabasePath is the SQLite database file.
+	DatabasePath string
+	// SeedProfile picks the fixtures loaded on startup: "dev", "demo",
+	// "test" or "prod-none" (default), which seeds nothing.
+	SeedProfile string
+	// TxMaxAttempts is how often a transaction that conflicts with a
+	// concurrent one (SQLite busy, Postgres serialization failure) is tried.
+	TxMaxAttempts int
+	// RedisAddr is built from REDIS_HOST and REDIS_PORT.
+	RedisAddr string
+	// AircraftDeletePolicy decides what happens to vouchers when their
+	// aircraft is deleted: "block" (default) or "cascade".
+	AircraftDeletePolicy string
+	// ArchiveRetention is how long after the flight date vouchers stay in the
+	// vouchers table before the archival job moves them. Zero disables the job.
+	ArchiveRetention time.Duration
+	// ArchiveInterval is how often the archival job runs. It must be
+	// positive; other values fall back to the default.
+	ArchiveInterval time.Duration
+	// IdempotencyTTL is how long responses to requests carrying an
+	// Idempotency-Key header are kept for replay.
+	IdempotencyTTL time.Duration
+	// DefaultTimezone is the IANA time zone used to resolve flight dates
+	// given as timestamps without a departure airport.
+	DefaultTimezone string
+	// Voucher generation is limited to this many requests per window for
+	// each client IP, X-API-Key header and crew_id. Zero disables a limit.
+	RateLimitWindow    time.Duration
+	RateLimitPerIP     int
+	RateLimitPerAPIKey int
+	RateLimitPerCrew   int
+	// TrustedProxies may set X-Forwarded-For to report the client IP used
+	// for rate limiting. Requests from anywhere else cannot spoof it.
+	TrustedProxies []string
+	// RequestTimeout is the deadline for handling a single request, after
+	// which its database and Redis calls are cancelled. Zero disables it.
+	RequestTimeout time.Duration
+	// TracingExporter is where OpenTelemetry spans go: "none" (default),
+	// "stdout", "file" (TracingFile) or "otlp".
+	TracingExporter    string
+	TracingFile        string
+	TracingSampleRatio float64
+	// LogLevel is the minimum level logged ("debug", "info", "warn" or
+	// "error"). LogModuleLevels overrides it per module, e.g. "gorm=warn".
+	LogLevel        string
+	LogModuleLevels string
+	// LogFormat is "json" (default) or "text".
+	LogFormat string
+	// LogRedactFields lists log fields holding PII whose values are never
+	// written. Defaults to crew_name.
+	LogRedactFields []string
+	// HealthCheckTimeout bounds each dependency check of /healthz and /readyz.
+	HealthCheckTimeout time.Duration
+	// HTTPAddr is where the server listens. The timeouts bound reading a
+	// request, writing its response and keeping idle connections open; the
+	// write timeout must exceed RequestTimeout.
+	HTTPAddr              string
+	HTTPReadTimeout       time.Duration
+	HTTPReadHeaderTimeout time.Duration
+	HTTPWriteTimeout      time.Duration
+	HTTPIdleTimeout       time.Duration
+	// ShutdownTimeout is how long in-flight requests, background workers
+	// and resource cleanup get after SIGTERM before the process exits.
+	ShutdownTimeout time.Duration
+	// Webhook deliveries are POSTed with WebhookTimeout and retried with a
+	// delay starting at WebhookRetryBaseDelay and doubling up to
+	// WebhookRetryMaxDelay. After WebhookMaxAttempts they are dead-lettered.
+	// The dispatcher looks for due deliveries every WebhookPollInterval.
+	WebhookTimeout        time.Duration
+	WebhookMaxAttempts    int
+	WebhookRetryBaseDelay time.Duration
+	WebhookRetryMaxDelay  time.Duration
+	WebhookPollInterval   time.Duration
+	// WebhookAllowInsecureURLs accepts subscriptions to plain http URLs and
+	// loopback or private hosts. It defaults to on for the "dev" seed profile
+	// only.
+	WebhookAllowInsecureURLs bool
+	// Voucher and aircraft events are relayed from the outbox table to the
+	// EventStream Redis stream every OutboxRelayInterval, which falls back
+	// to the default unless positive. The stream is trimmed to about
+	// EventStreamMaxLen entries; zero keeps every entry. EventConsumerGroups
+	// are created on startup if missing. Relayed events stay in the outbox
+	// for OutboxRetention.
+	EventStream         string
+	EventStreamMaxLen   int
+	EventConsumerGroups []string
+	OutboxRelayInterval time.Duration
+	OutboxRetention     time.Duration
+}
+
+// Load reads the application configuration from environment variables.
+func Load() Config {
+	seedProfile := getEnv("SEED_PROFILE", "prod-none")
+	return Config{
+		DatabasePath:             getEnv("DATABASE_PATH", "vsa.db"),
+		SeedProfile:              seedProfile,
+		TxMaxAttempts:            getEnvInt("TX_MAX_ATTEMPTS", 5),
+		RedisAddr:                getEnv("REDIS_HOST", "redis") + ":" + getEnv("REDIS_PORT", "6379"),
+		AircraftDeletePolicy:     getEnv("AIRCRAFT_DELETE_POLICY", "block"),
+		ArchiveRetention:         time.Duration(getEnvInt("ARCHIVE_RETENTION_DAYS", 365)) * 24 * time.Hour,
+		ArchiveInterval:          getEnvInterval("ARCHIVE_INTERVAL", 24*time.Hour),
+		IdempotencyTTL:           getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		DefaultTimezone:          getEnv("DEFAULT_TIMEZONE", "Asia/Jakarta"),
+		RateLimitWindow:          getEnvDuration("RATE_LIMIT_WINDOW", time.Minute),
+		RateLimitPerIP:           getEnvInt("RATE_LIMIT_PER_IP", 30),
+		RateLimitPerAPIKey:       getEnvInt("RATE_LIMIT_PER_API_KEY", 120),
+		RateLimitPerCrew:         getEnvInt("RATE_LIMIT_PER_CREW", 5),
+		TrustedProxies:           getEnvList("TRUSTED_PROXIES"),
+		RequestTimeout:           getEnvDuration("REQUEST_TIMEOUT", 10*time.Second),
+		TracingExporter:          getEnv("TRACING_EXPORTER", "none"),
+		TracingFile:              getEnv("TRACING_FILE", "traces.json"),
+		TracingSampleRatio:       getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		LogLevel:                 getEnv("LOG_LEVEL", "info"),
+		LogModuleLevels:          getEnv("LOG_MODULE_LEVELS", ""),
+		LogFormat:                getEnv("LOG_FORMAT", "json"),
+		LogRedactFields:          getEnvList("LOG_REDACT_FIELDS", "crew_name"),
+		HealthCheckTimeout:       getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		HTTPAddr:                 getEnv("HTTP_ADDR", ":8081"),
+		HTTPReadTimeout:          getEnvDuration("HTTP_READ_TIMEOUT", 15*time.Second),
+		HTTPReadHeaderTimeout:    getEnvDuration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second),
+		HTTPWriteTimeout:         getEnvDuration("HTTP_WRITE_TIMEOUT", 30*time.Second),
+		HTTPIdleTimeout:          getEnvDuration("HTTP_IDLE_TIMEOUT", 60*time.Second),
+		ShutdownTimeout:          getEnvDuration("SHUTDOWN_TIMEOUT", 20*time.Second),
+		WebhookTimeout:           getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookMaxAttempts:       getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookRetryBaseDelay:    getEnvDuration("WEBHOOK_RETRY_BASE_DELAY", 30*time.Second),
+		WebhookRetryMaxDelay:     getEnvDuration("WEBHOOK_RETRY_MAX_DELAY", time.Hour),
+		WebhookPollInterval:      getEnvInterval("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		WebhookAllowInsecureURLs: getEnvBool("WEBHOOK_ALLOW_INSECURE_URLS", seedProfile == "dev"),
+		EventStream:              getEnv("EVENT_STREAM", "vsa:events"),
+		EventStreamMaxLen:        getEnvInt("EVENT_STREAM_MAXLEN", 1000000),
+		EventConsumerGroups:      getEnvList("EVENT_CONSUMER_GROUPS", "data-platform"),
+		OutboxRelayInterval:      getEnvInterval("OUTBOX_RELAY_INTERVAL", time.Second),
+		OutboxRetention:          getEnvDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvList(key string, fallback ...string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, strings.Join(fallback, ",")), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvInterval reads the period of a background job. Tickers cannot run
+// with a zero or negative period, so such values fall back like unparsable
+// ones.
+func getEnvInterval(key string, fallback time.Duration) time.Duration {
+	if value := getEnvDuration(key, fallback); value > 0 {
+		return value
+	}
+	return fallback
+}
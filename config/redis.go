@@ -2,21 +2,25 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
-var Ctx = context.Background()
-
-func InitRedis() *redis.Client {
+// InitRedis connects to Redis at addr, failing when it does not answer a
+// ping within five seconds.
+func InitRedis(addr string) (*redis.Client, error) {
 	rdb := redis.NewClient(&redis.Options{
-		Addr: "redis:6379", // Docker service name
+		Addr: addr,
 	})
 
-	_, err := rdb.Ping(Ctx).Result()
-	if err != nil {
-		panic(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		rdb.Close()
+		return nil, err
 	}
 
-	return rdb
+	return rdb, nil
 }
@@ -3,17 +3,18 @@ package controllers
 import (
 	"net/http"
 
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/services"
+
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
-	"VSA_GOGIN_BE/models"
 )
 
 type AircraftController struct {
-	DB *gorm.DB
+	Service *services.AircraftService
 }
 
-func NewAircraftController(db *gorm.DB) *AircraftController {
-	return &AircraftController{DB: db}
+func NewAircraftController(service *services.AircraftService) *AircraftController {
+	return &AircraftController{Service: service}
 }
 
 // CreateAircraft godoc
@@ -22,31 +23,24 @@ func NewAircraftController(db *gorm.DB) *AircraftController {
 // @Tags aircraft
 // @Accept json
 // @Produce json
-// @Success 201 {object} models.Aircraft
+// @Param request body dto.AircraftRequest true "Aircraft details"
+// @Success 201 {object} dto.AircraftResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 409 {object} dto.ErrorResponse "Aircraft type already exists"
 // @Router /aircraft [post]
 func (c *AircraftController) CreateAircraft(ctx *gin.Context) {
-	var aircraft models.Aircraft
-	if err := ctx.ShouldBindJSON(&aircraft); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Validation: NumRows must be > 0, SeatsPerRow must not be empty
-	if aircraft.NumRows <= 0 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "num_rows must be greater than 0"})
-		return
-	}
-	if aircraft.SeatsPerRow == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "seats_per_row must not be empty"})
+	var req dto.AircraftRequest
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	if err := c.DB.Create(&aircraft).Error; err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	aircraft := req.ToModel()
+	if err := c.Service.CreateAircraft(ctx.Request.Context(), &aircraft); err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, aircraft)
+	ctx.JSON(http.StatusCreated, dto.NewAircraftResponse(aircraft))
 }
 
 // GetAircraft godoc
@@ -55,16 +49,22 @@ func (c *AircraftController) CreateAircraft(ctx *gin.Context) {
 // @Tags aircraft
 // @Produce json
 // @Param id path int true "Aircraft ID"
-// @Success 200 {object} models.Aircraft
+// @Success 200 {object} dto.AircraftResponse
+// @Failure 404 {object} dto.ErrorResponse "Aircraft not found"
 // @Router /aircraft/{id} [get]
 func (c *AircraftController) GetAircraft(ctx *gin.Context) {
-	var aircraft models.Aircraft
-	if err := c.DB.First(&aircraft, ctx.Param("id")).Error; err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Aircraft not found"})
+	id, ok := parseID(ctx)
+	if !ok {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, aircraft)
+	aircraft, err := c.Service.GetAircraft(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewAircraftResponse(*aircraft))
 }
 
 // ListAircraft godoc
@@ -72,16 +72,22 @@ func (c *AircraftController) GetAircraft(ctx *gin.Context) {
 // @Description Get all aircraft
 // @Tags aircraft
 // @Produce json
-// @Success 200 {array} models.Aircraft
+// @Param include_deleted query bool false "Include soft-deleted aircraft"
+// @Success 200 {array} dto.AircraftResponse
 // @Router /aircraft [get]
 func (c *AircraftController) ListAircraft(ctx *gin.Context) {
-	var aircraft []models.Aircraft
-	if err := c.DB.Find(&aircraft).Error; err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query dto.ListQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	aircraft, err := c.Service.ListAircraft(ctx.Request.Context(), query.IncludeDeleted)
+	if err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, aircraft)
+	ctx.JSON(http.StatusOK, dto.NewAircraftResponses(aircraft))
 }
 
 // UpdateAircraft godoc
@@ -91,50 +97,81 @@ func (c *AircraftController) ListAircraft(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Aircraft ID"
-// @Success 200 {object} models.Aircraft
+// @Param request body dto.AircraftRequest true "Aircraft details"
+// @Success 200 {object} dto.AircraftResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 404 {object} dto.ErrorResponse "Aircraft not found"
+// @Failure 409 {object} dto.ErrorResponse "Aircraft type already exists"
 // @Router /aircraft/{id} [put]
 func (c *AircraftController) UpdateAircraft(ctx *gin.Context) {
-	var aircraft models.Aircraft
-	if err := c.DB.First(&aircraft, ctx.Param("id")).Error; err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Aircraft not found"})
+	id, ok := parseID(ctx)
+	if !ok {
 		return
 	}
 
-	if err := ctx.ShouldBindJSON(&aircraft); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	aircraft, err := c.Service.GetAircraft(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	// Validation: NumRows must be > 0, SeatsPerRow must not be empty
-	if aircraft.NumRows <= 0 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "num_rows must be greater than 0"})
-		return
-	}
-	if aircraft.SeatsPerRow == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "seats_per_row must not be empty"})
+	var req dto.AircraftRequest
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	if err := c.DB.Save(&aircraft).Error; err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	req.ApplyTo(aircraft)
+	if err := c.Service.UpdateAircraft(ctx.Request.Context(), aircraft); err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, aircraft)
+	ctx.JSON(http.StatusOK, dto.NewAircraftResponse(*aircraft))
 }
 
 // DeleteAircraft godoc
 // @Summary Delete an aircraft
-// @Description Delete aircraft by ID
+// @Description Soft-delete aircraft by ID. Depending on the delete policy, aircraft still referenced by vouchers are either kept or deleted together with their vouchers.
 // @Tags aircraft
 // @Param id path int true "Aircraft ID"
 // @Success 204 "No Content"
+// @Failure 404 {object} dto.ErrorResponse "Aircraft not found"
+// @Failure 409 {object} dto.ErrorResponse "Aircraft referenced by vouchers"
 // @Router /aircraft/{id} [delete]
 func (c *AircraftController) DeleteAircraft(ctx *gin.Context) {
-	if err := c.DB.Delete(&models.Aircraft{}, ctx.Param("id")).Error; err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.Service.DeleteAircraft(ctx.Request.Context(), id); err != nil {
+		respondError(ctx, err)
 		return
 	}
 
 	ctx.Status(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// RestoreAircraft godoc
+// @Summary Restore a deleted aircraft
+// @Description Restore a soft-deleted aircraft by ID
+// @Tags aircraft
+// @Produce json
+// @Param id path int true "Aircraft ID"
+// @Success 200 {object} dto.AircraftResponse
+// @Failure 404 {object} dto.ErrorResponse "Aircraft not found"
+// @Router /aircraft/{id}/restore [post]
+func (c *AircraftController) RestoreAircraft(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	aircraft, err := c.Service.RestoreAircraft(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewAircraftResponse(*aircraft))
+}
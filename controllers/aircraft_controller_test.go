@@ -0,0 +1,140 @@
+package controllers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"VSA_GOGIN_BE/controllers"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/repositories/memory"
+	"VSA_GOGIN_BE/routes"
+	"VSA_GOGIN_BE/services"
+	"VSA_GOGIN_BE/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// aircraftFixture serves the aircraft routes backed by in-memory
+// repositories holding an Airbus A320 (ID 1), with a voucher when inUse is
+// set, a Boeing 737 (ID 2) and a deleted Fokker 100 (ID 3).
+type aircraftFixture struct {
+	router   *gin.Engine
+	aircraft repositories.AircraftRepository
+	vouchers repositories.VoucherRepository
+}
+
+func newAircraftFixture(t *testing.T, policy services.DeletePolicy, inUse bool) aircraftFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	validators.Register()
+
+	ctx := context.Background()
+	vouchers := memory.NewVoucherRepository()
+	aircraft := memory.NewAircraftRepository(vouchers)
+	a320 := models.Aircraft{AircraftType: "Airbus A320", AircraftTypeKey: "airbus_a320", NumRows: 32, SeatsPerRow: "ABC-DEF"}
+	b737 := models.Aircraft{AircraftType: "Boeing 737", AircraftTypeKey: "boeing_737", NumRows: 30, SeatsPerRow: "ABC-DEF"}
+	f100 := models.Aircraft{AircraftType: "Fokker 100", AircraftTypeKey: "fokker_100", NumRows: 20, SeatsPerRow: "AC-DEF"}
+	for _, a := range []*models.Aircraft{&a320, &b737, &f100} {
+		if err := aircraft.Create(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := aircraft.Delete(ctx, &f100); err != nil {
+		t.Fatal(err)
+	}
+	if inUse {
+		voucher := models.Voucher{CrewID: "C1", FlightNumber: "GA100", FlightDate: models.NewDate(2025, 12, 1), AircraftID: &a320.ID, AircraftTypeKey: a320.AircraftTypeKey, Seat1: "1A"}
+		if err := vouchers.Create(ctx, &voucher); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	audit := services.NewAuditService(memory.NewAuditLogRepository())
+	service := services.NewAircraftService(aircraft, vouchers, audit, nil, policy, memory.NewTransactor(), nil)
+	router := gin.New()
+	routes.SetupAircraftRoutes(router, controllers.NewAircraftController(service))
+	return aircraftFixture{router: router, aircraft: aircraft, vouchers: vouchers}
+}
+
+func (f aircraftFixture) serve(method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAircraftControllerStatusCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy services.DeletePolicy
+		inUse  bool
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"create", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":"ATR 72","num_rows":18,"seats_per_row":"AB-CD"}`, http.StatusCreated},
+		{"create existing type", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":"Airbus A320","num_rows":18,"seats_per_row":"AB-CD"}`, http.StatusConflict},
+		{"create type of a deleted aircraft", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":"Fokker 100","num_rows":20,"seats_per_row":"AC-DEF"}`, http.StatusConflict},
+		{"create without rows", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":"ATR 72","num_rows":0,"seats_per_row":"AB-CD"}`, http.StatusBadRequest},
+		{"create with malformed body", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":`, http.StatusBadRequest},
+		{"create blocking a missing seat", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":"ATR 72","num_rows":18,"seats_per_row":"AB-CD","blocked_seats":["1F"]}`, http.StatusBadRequest},
+		{"create blocking an empty seat", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":"ATR 72","num_rows":18,"seats_per_row":"AB-CD","blocked_seats":["1A","","1A"]}`, http.StatusBadRequest},
+		{"create blocking a malformed seat", services.DeletePolicyBlock, false, "POST", "/api/aircraft/", `{"aircraft_type":"ATR 72","num_rows":18,"seats_per_row":"AB-CD","blocked_seats":["1A;2B"]}`, http.StatusBadRequest},
+		{"update", services.DeletePolicyBlock, false, "PUT", "/api/aircraft/1", `{"aircraft_type":"Airbus A320neo","num_rows":30,"seats_per_row":"ABC-DEF"}`, http.StatusOK},
+		{"update with invalid id", services.DeletePolicyBlock, false, "PUT", "/api/aircraft/abc", `{"aircraft_type":"ATR 72","num_rows":18,"seats_per_row":"AB-CD"}`, http.StatusBadRequest},
+		{"update missing aircraft", services.DeletePolicyBlock, false, "PUT", "/api/aircraft/9", `{"aircraft_type":"ATR 72","num_rows":18,"seats_per_row":"AB-CD"}`, http.StatusNotFound},
+		{"update with invalid seats", services.DeletePolicyBlock, false, "PUT", "/api/aircraft/1", `{"aircraft_type":"Airbus A320","num_rows":32,"seats_per_row":"abc"}`, http.StatusBadRequest},
+		{"update to a taken type", services.DeletePolicyBlock, false, "PUT", "/api/aircraft/2", `{"aircraft_type":"Airbus A320","num_rows":30,"seats_per_row":"ABC-DEF"}`, http.StatusConflict},
+		{"delete", services.DeletePolicyBlock, false, "DELETE", "/api/aircraft/1", ``, http.StatusNoContent},
+		{"delete missing aircraft", services.DeletePolicyBlock, false, "DELETE", "/api/aircraft/9", ``, http.StatusNotFound},
+		{"delete aircraft in use", services.DeletePolicyBlock, true, "DELETE", "/api/aircraft/1", ``, http.StatusConflict},
+		{"delete aircraft in use with cascade", services.DeletePolicyCascade, true, "DELETE", "/api/aircraft/1", ``, http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAircraftFixture(t, tt.policy, tt.inUse)
+			if w := f.serve(tt.method, tt.path, tt.body); w.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.path, w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+func TestDeleteAircraftCascadeDeletesVouchers(t *testing.T) {
+	f := newAircraftFixture(t, services.DeletePolicyCascade, true)
+	if w := f.serve("DELETE", "/api/aircraft/1", ``); w.Code != http.StatusNoContent {
+		t.Fatalf("DELETE = %d, want %d: %s", w.Code, http.StatusNoContent, w.Body)
+	}
+
+	if count, _ := f.vouchers.CountByAircraftID(context.Background(), 1); count != 0 {
+		t.Errorf("%d vouchers left for the deleted aircraft, want 0", count)
+	}
+	if w := f.serve("GET", "/api/aircraft/1", ``); w.Code != http.StatusNotFound {
+		t.Errorf("GET deleted aircraft = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestCreateAircraftStoresBlockedSeatsOnce(t *testing.T) {
+	f := newAircraftFixture(t, services.DeletePolicyBlock, false)
+	body := `{"aircraft_type":"ATR 72","num_rows":18,"seats_per_row":"AB-CD","blocked_seats":["1A","2B","1A"]}`
+	if w := f.serve("POST", "/api/aircraft/", body); w.Code != http.StatusCreated {
+		t.Fatalf("POST = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+
+	aircraft, err := f.aircraft.FindByKey(context.Background(), "atr_72")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aircraft.BlockedSeats != "1A,2B" {
+		t.Errorf("blocked seats = %q, want %q", aircraft.BlockedSeats, "1A,2B")
+	}
+	if got, want := aircraft.AssignableSeatCount(), 18*4-2; got != want {
+		t.Errorf("assignable seats = %d, want %d", got, want)
+	}
+}
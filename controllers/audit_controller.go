@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultAuditLogLimit caps list responses when the caller sets no limit.
+const defaultAuditLogLimit = 100
+
+type AuditController struct {
+	Service *services.AuditService
+}
+
+func NewAuditController(service *services.AuditService) *AuditController {
+	return &AuditController{Service: service}
+}
+
+// ListAuditLogs godoc
+// @Summary List audit log entries
+// @Description Query the append-only audit log of aircraft, voucher and webhook subscription changes
+// @Tags audit
+// @Produce json
+// @Param entity query string false "Entity type" Enums(aircraft, voucher, webhook)
+// @Param entity_id query int false "Entity ID"
+// @Param actor query string false "Actor"
+// @Param action query string false "Action" Enums(create, update, delete, restore, generate, regenerate)
+// @Param request_id query string false "Request ID"
+// @Param from query string false "Created at or after (RFC 3339)"
+// @Param to query string false "Created before (RFC 3339)"
+// @Param limit query int false "Maximum number of entries (default 100, max 1000)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {array} dto.AuditLogResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Router /audit [get]
+func (c *AuditController) ListAuditLogs(ctx *gin.Context) {
+	var query dto.AuditLogQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultAuditLogLimit
+	}
+
+	entries, err := c.Service.ListAuditLogs(ctx.Request.Context(), query.ToFilter())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewAuditLogResponses(entries))
+}
+
+// ExportAuditLogs godoc
+// @Summary Export audit log entries
+// @Description Download every audit log entry matching the filters as JSON or CSV for compliance reviews
+// @Tags audit
+// @Produce json
+// @Produce text/csv
+// @Param format query string false "Export format" Enums(json, csv)
+// @Param entity query string false "Entity type" Enums(aircraft, voucher, webhook)
+// @Param entity_id query int false "Entity ID"
+// @Param actor query string false "Actor"
+// @Param action query string false "Action" Enums(create, update, delete, restore, generate, regenerate)
+// @Param request_id query string false "Request ID"
+// @Param from query string false "Created at or after (RFC 3339)"
+// @Param to query string false "Created before (RFC 3339)"
+// @Success 200 {array} dto.AuditLogResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Router /audit/export [get]
+func (c *AuditController) ExportAuditLogs(ctx *gin.Context) {
+	var query dto.AuditLogExportQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	entries, err := c.Service.ListAuditLogs(ctx.Request.Context(), query.ToFilter())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	filename := "audit-" + time.Now().UTC().Format("20060102T150405Z")
+	if query.Format != "csv" {
+		ctx.Header("Content-Disposition", `attachment; filename="`+filename+`.json"`)
+		ctx.JSON(http.StatusOK, dto.NewAuditLogResponses(entries))
+		return
+	}
+
+	header := []string{"id", "created_at", "actor", "action", "entity", "entity_id", "request_id", "remote_addr", "before", "after", "diff", "seats_considered", "seats_chosen"}
+	records := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, []string{
+			strconv.FormatUint(uint64(e.ID), 10),
+			e.CreatedAt.UTC().Format(time.RFC3339),
+			e.Actor,
+			e.Action,
+			e.Entity,
+			strconv.FormatUint(uint64(e.EntityID), 10),
+			e.RequestID,
+			e.RemoteAddr,
+			e.Before,
+			e.After,
+			e.Diff,
+			e.SeatsConsidered,
+			e.SeatsChosen,
+		})
+	}
+	// Actors and crew data are client supplied, so cells are escaped
+	// against spreadsheet formulas like every other CSV export.
+	respondCSV(ctx, "audit", header, records)
+}
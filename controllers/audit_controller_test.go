@@ -0,0 +1,55 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"VSA_GOGIN_BE/controllers"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories/memory"
+	"VSA_GOGIN_BE/routes"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestExportAuditLogsEscapesFormulas(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logs := memory.NewAuditLogRepository()
+	// The actor comes from the X-Actor header, which any client can set.
+	entry := models.AuditLog{Actor: `=HYPERLINK("http://evil.example","open")`, Action: "create", Entity: "voucher", EntityID: 1, RequestID: "@req"}
+	if err := logs.Create(context.Background(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	router := gin.New()
+	routes.SetupAuditRoutes(router, controllers.NewAuditController(services.NewAuditService(logs)))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/audit/export?format=csv", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api/audit/export = %d, want %d", rec.Code, http.StatusOK)
+	}
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("export has %d rows, want a header and 1 entry", len(records))
+	}
+	row := map[string]string{}
+	for i, column := range records[0] {
+		row[column] = records[1][i]
+	}
+	if want := "'" + entry.Actor; row["actor"] != want {
+		t.Errorf("actor = %q, want %q", row["actor"], want)
+	}
+	if row["request_id"] != "'@req" {
+		t.Errorf("request_id = %q, want %q", row["request_id"], "'@req")
+	}
+	if row["action"] != "create" {
+		t.Errorf("action = %q, want create", row["action"])
+	}
+}
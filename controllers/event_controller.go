@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"net/http"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultEventCount caps stream reads when the caller sets no count.
+const defaultEventCount = 100
+
+type EventController struct {
+	Service *services.OutboxService
+}
+
+func NewEventController(service *services.OutboxService) *EventController {
+	return &EventController{Service: service}
+}
+
+// ReadEvents godoc
+// @Summary Read the event stream
+// @Description Page through the voucher and aircraft change events relayed to the Redis stream, oldest first, without involving a consumer group
+// @Tags events
+// @Produce json
+// @Param after query string false "Stream ID of the last entry already read; empty starts at the beginning"
+// @Param count query int false "Maximum number of entries (default 100, max 1000)"
+// @Success 200 {object} dto.EventStreamResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid offset"
+// @Router /events [get]
+func (c *EventController) ReadEvents(ctx *gin.Context) {
+	var query dto.EventStreamQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+	if query.Count == 0 {
+		query.Count = defaultEventCount
+	}
+
+	events, err := c.Service.ReadStream(ctx.Request.Context(), query.After, query.Count)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewEventStreamResponse(events))
+}
+
+// GetStreamInfo godoc
+// @Summary Describe the event stream
+// @Description Get the length of the event stream, how far each consumer group has read it and how many outbox events wait to be relayed
+// @Tags events
+// @Produce json
+// @Success 200 {object} dto.EventStreamInfoResponse
+// @Router /events/stream [get]
+func (c *EventController) GetStreamInfo(ctx *gin.Context) {
+	info, err := c.Service.StreamInfo(ctx.Request.Context())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewEventStreamInfoResponse(*info))
+}
+
+// ReplayEvents godoc
+// @Summary Replay the event stream to a consumer group
+// @Description Move the consumer group back (or forward) so that its consumers read every entry after the given stream ID again. Entries read but not acknowledged stay pending.
+// @Tags events
+// @Accept json
+// @Param group path string true "Consumer group"
+// @Param request body dto.EventReplayRequest true "Offset to replay from"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid offset"
+// @Failure 404 {object} dto.ErrorResponse "Consumer group not found"
+// @Router /events/groups/{group}/replay [post]
+func (c *EventController) ReplayEvents(ctx *gin.Context) {
+	var req dto.EventReplayRequest
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	if err := c.Service.Replay(ctx.Request.Context(), ctx.Param("group"), req.From); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"net/http"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FlightController struct {
+	Service *services.VoucherService
+}
+
+func NewFlightController(service *services.VoucherService) *FlightController {
+	return &FlightController{Service: service}
+}
+
+// GetSeatMap godoc
+// @Summary Get flight seat map
+// @Description Get the cabin layout of a flight with each seat's position and status: free, assigned to a voucher or blocked. The aircraft is the one the flight's vouchers were issued for unless aircraft_type_key is given. Set format to ascii or svg for a rendering ops can read at a glance.
+// @Tags flights
+// @Produce json
+// @Produce plain
+// @Produce image/svg+xml
+// @Param number path string true "Flight number" example(ID001)
+// @Param date path string true "Local departure date (YYYY-MM-DD)" example(2025-12-01)
+// @Param departure_airport query string false "Departure airport IATA code, used to convert timestamps"
+// @Param aircraft_type_key query string false "Aircraft type key, required when the flight has no vouchers"
+// @Param format query string false "Response format (default json)" Enums(json, ascii, svg)
+// @Success 200 {object} dto.SeatMapResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 404 {object} dto.ErrorResponse "Flight or aircraft not found"
+// @Failure 500 {object} dto.ErrorResponse "Server error"
+// @Router /flights/{number}/{date}/seatmap [get]
+func (c *FlightController) GetSeatMap(ctx *gin.Context) {
+	var req dto.SeatMapRequest
+	if !bindURI(ctx, &req) || !bindQuery(ctx, &req) {
+		return
+	}
+
+	flightDate, err := req.Date()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(ctx.Request.Context(), err.Error()))
+		return
+	}
+
+	seatMap, err := c.Service.SeatMap(ctx.Request.Context(), req.FlightNumber, flightDate, req.AircraftTypeKey)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	switch req.Format {
+	case dto.SeatMapFormatASCII:
+		ctx.String(http.StatusOK, renderSeatMapASCII(seatMap))
+	case dto.SeatMapFormatSVG:
+		ctx.Data(http.StatusOK, "image/svg+xml; charset=utf-8", []byte(renderSeatMapSVG(seatMap)))
+	default:
+		ctx.JSON(http.StatusOK, dto.NewSeatMapResponse(seatMap))
+	}
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"net/http"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	Service *services.HealthService
+}
+
+func NewHealthController(service *services.HealthService) *HealthController {
+	return &HealthController{Service: service}
+}
+
+// Healthz reports whether the process is alive. Only failures a restart
+// could fix, such as a broken database handle, make it fail. The probes live
+// outside the /api base path, so they are not part of the Swagger docs.
+func (c *HealthController) Healthz(ctx *gin.Context) {
+	results, healthy := c.Service.Live(ctx.Request.Context())
+	respondHealth(ctx, results, healthy)
+}
+
+// Readyz reports whether the service can handle requests: the database and
+// Redis are reachable, migrations are applied and seeding has completed.
+func (c *HealthController) Readyz(ctx *gin.Context) {
+	results, healthy := c.Service.Ready(ctx.Request.Context())
+	respondHealth(ctx, results, healthy)
+}
+
+func respondHealth(ctx *gin.Context, results []services.HealthCheckResult, healthy bool) {
+	status := http.StatusOK
+	if !healthy {
+		status = http.StatusServiceUnavailable
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(status, dto.NewHealthResponse(results, healthy))
+}
@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultReportLimit caps report rows when the caller sets no limit.
+const defaultReportLimit = 100
+
+type ReportController struct {
+	Service *services.ReportService
+}
+
+func NewReportController(service *services.ReportService) *ReportController {
+	return &ReportController{Service: service}
+}
+
+// FlightReport godoc
+// @Summary Report seat utilization per flight
+// @Description Seats assigned, seats remaining and fill rate of every flight with active vouchers, ordered by flight date. Blocked seats do not count towards capacity.
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "First flight date (YYYY-MM-DD)"
+// @Param to query string false "Last flight date (YYYY-MM-DD)"
+// @Param aircraft_type_key query string false "Aircraft type key"
+// @Param crew_base query string false "Crew base IATA code"
+// @Param format query string false "Response format (default json)" Enums(json, csv)
+// @Param limit query int false "Maximum number of rows (default 100, max 1000)"
+// @Param offset query int false "Number of rows to skip"
+// @Success 200 {array} dto.FlightReportResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 500 {object} dto.ErrorResponse "Server error"
+// @Router /reports/flights [get]
+func (c *ReportController) FlightReport(ctx *gin.Context) {
+	query, filter, ok := bindReportQuery(ctx)
+	if !ok {
+		return
+	}
+
+	flights, err := c.Service.FlightUtilization(ctx.Request.Context(), filter)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	responses := dto.NewFlightReportResponses(flights)
+	if query.Format != "csv" {
+		ctx.JSON(http.StatusOK, responses)
+		return
+	}
+	records := make([][]string, 0, len(responses))
+	for _, r := range responses {
+		records = append(records, r.CSVRecord())
+	}
+	respondCSV(ctx, "report-flights", dto.FlightReportCSVHeader, records)
+}
+
+// AircraftTypeReport godoc
+// @Summary Report seat utilization per aircraft type
+// @Description Flights, vouchers and fill rate of every aircraft type over its flights with active vouchers, ordered by aircraft type key.
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "First flight date (YYYY-MM-DD)"
+// @Param to query string false "Last flight date (YYYY-MM-DD)"
+// @Param aircraft_type_key query string false "Aircraft type key"
+// @Param crew_base query string false "Crew base IATA code"
+// @Param format query string false "Response format (default json)" Enums(json, csv)
+// @Param limit query int false "Maximum number of rows (default 100, max 1000)"
+// @Param offset query int false "Number of rows to skip"
+// @Success 200 {array} dto.AircraftTypeReportResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 500 {object} dto.ErrorResponse "Server error"
+// @Router /reports/aircraft-types [get]
+func (c *ReportController) AircraftTypeReport(ctx *gin.Context) {
+	query, filter, ok := bindReportQuery(ctx)
+	if !ok {
+		return
+	}
+
+	types, err := c.Service.AircraftTypeUtilization(ctx.Request.Context(), filter)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	responses := dto.NewAircraftTypeReportResponses(types)
+	if query.Format != "csv" {
+		ctx.JSON(http.StatusOK, responses)
+		return
+	}
+	records := make([][]string, 0, len(responses))
+	for _, r := range responses {
+		records = append(records, r.CSVRecord())
+	}
+	respondCSV(ctx, "report-aircraft-types", dto.AircraftTypeReportCSVHeader, records)
+}
+
+// CrewBaseReport godoc
+// @Summary Report voucher usage per crew base
+// @Description Vouchers, crew members, flights and seats per crew base, busiest first. Vouchers issued without a crew base are grouped under an empty crew_base.
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "First flight date (YYYY-MM-DD)"
+// @Param to query string false "Last flight date (YYYY-MM-DD)"
+// @Param aircraft_type_key query string false "Aircraft type key"
+// @Param crew_base query string false "Crew base IATA code"
+// @Param format query string false "Response format (default json)" Enums(json, csv)
+// @Param limit query int false "Maximum number of rows (default 100, max 1000)"
+// @Param offset query int false "Number of rows to skip"
+// @Success 200 {array} dto.CrewBaseReportResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 500 {object} dto.ErrorResponse "Server error"
+// @Router /reports/crew-bases [get]
+func (c *ReportController) CrewBaseReport(ctx *gin.Context) {
+	query, filter, ok := bindReportQuery(ctx)
+	if !ok {
+		return
+	}
+
+	rows, err := c.Service.CrewBaseUsage(ctx.Request.Context(), filter)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	responses := dto.NewCrewBaseReportResponses(rows)
+	if query.Format != "csv" {
+		ctx.JSON(http.StatusOK, responses)
+		return
+	}
+	records := make([][]string, 0, len(responses))
+	for _, r := range responses {
+		records = append(records, r.CSVRecord())
+	}
+	respondCSV(ctx, "report-crew-bases", dto.CrewBaseReportCSVHeader, records)
+}
+
+// CrewReport godoc
+// @Summary Report voucher usage per crew member
+// @Description Vouchers, flights and seats per crew member with their first and last flight, busiest first
+// @Tags reports
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "First flight date (YYYY-MM-DD)"
+// @Param to query string false "Last flight date (YYYY-MM-DD)"
+// @Param aircraft_type_key query string false "Aircraft type key"
+// @Param crew_base query string false "Crew base IATA code"
+// @Param format query string false "Response format (default json)" Enums(json, csv)
+// @Param limit query int false "Maximum number of rows (default 100, max 1000)"
+// @Param offset query int false "Number of rows to skip"
+// @Success 200 {array} dto.CrewReportResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 500 {object} dto.ErrorResponse "Server error"
+// @Router /reports/crew [get]
+func (c *ReportController) CrewReport(ctx *gin.Context) {
+	query, filter, ok := bindReportQuery(ctx)
+	if !ok {
+		return
+	}
+
+	rows, err := c.Service.CrewUsage(ctx.Request.Context(), filter)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	responses := dto.NewCrewReportResponses(rows)
+	if query.Format != "csv" {
+		ctx.JSON(http.StatusOK, responses)
+		return
+	}
+	records := make([][]string, 0, len(responses))
+	for _, r := range responses {
+		records = append(records, r.CSVRecord())
+	}
+	respondCSV(ctx, "report-crew", dto.CrewReportCSVHeader, records)
+}
+
+// bindReportQuery binds the report filters, applying the default limit.
+func bindReportQuery(ctx *gin.Context) (dto.ReportQuery, repositories.ReportFilter, bool) {
+	var query dto.ReportQuery
+	if !bindQuery(ctx, &query) {
+		return query, repositories.ReportFilter{}, false
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultReportLimit
+	}
+
+	filter, err := query.ToFilter()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(ctx.Request.Context(), err.Error()))
+		return query, filter, false
+	}
+	return query, filter, true
+}
+
+// respondCSV writes the header and records as a CSV attachment named after
+// the report and the current time. The status is sent by then, so a write
+// error is only recorded on the context for the request log.
+func respondCSV(ctx *gin.Context, name string, header []string, records [][]string) {
+	filename := name + "-" + time.Now().UTC().Format("20060102T150405Z") + ".csv"
+	ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Status(http.StatusOK)
+
+	if err := dto.WriteCSV(ctx.Writer, header, records); err != nil {
+		ctx.Error(err)
+	}
+}
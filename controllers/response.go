@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/services"
+	"VSA_GOGIN_BE/validators"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSON binds the request body into req. When binding or validation fails
+// it writes a 400 response listing each failing field and returns false.
+func bindJSON(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		respondBindError(ctx, err)
+		return false
+	}
+	return true
+}
+
+// bindQuery binds the query string into req, writing a 400 response when a
+// parameter cannot be parsed or fails validation.
+func bindQuery(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		respondBindError(ctx, err)
+		return false
+	}
+	return true
+}
+
+// bindURI binds the path parameters into req, writing a 400 response when a
+// parameter fails validation.
+func bindURI(ctx *gin.Context, req interface{}) bool {
+	if err := ctx.ShouldBindUri(req); err != nil {
+		respondBindError(ctx, err)
+		return false
+	}
+	return true
+}
+
+func respondBindError(ctx *gin.Context, err error) {
+	if fields := validators.FieldErrors(err); fields != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{
+			Error:     "validation failed",
+			Fields:    fields,
+			RequestID: logging.RequestID(ctx.Request.Context()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(ctx.Request.Context(), err.Error()))
+}
+
+// statusClientClosedRequest is logged for requests whose client hung up
+// before the response was ready.
+const statusClientClosedRequest = 499
+
+// respondError writes err as a JSON error response, choosing the status code
+// from the service error it wraps.
+func respondError(ctx *gin.Context, err error) {
+	// Drivers report cancelled work in their own words, so go by the request
+	// context rather than err alone.
+	switch reqErr := ctx.Request.Context().Err(); {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(reqErr, context.DeadlineExceeded):
+		ctx.JSON(http.StatusGatewayTimeout, dto.NewErrorResponse(ctx.Request.Context(), "request timed out"))
+		return
+	case errors.Is(reqErr, context.Canceled):
+		ctx.AbortWithStatus(statusClientClosedRequest)
+		return
+	}
+
+	ctx.JSON(statusFor(err), dto.NewErrorResponse(ctx.Request.Context(), err.Error()))
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, services.ErrAircraftNotFound),
+		errors.Is(err, services.ErrVoucherNotFound),
+		errors.Is(err, services.ErrFlightNotFound),
+		errors.Is(err, services.ErrWebhookNotFound),
+		errors.Is(err, services.ErrDeliveryNotFound),
+		errors.Is(err, services.ErrConsumerGroupNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrInvalidAircraft),
+		errors.Is(err, services.ErrInvalidWebhook),
+		errors.Is(err, services.ErrInvalidStreamOffset):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrAircraftInUse),
+		errors.Is(err, services.ErrAircraftExists),
+		errors.Is(err, services.ErrDeliveryPending),
+		errors.Is(err, services.ErrVoucherExists),
+		errors.Is(err, services.ErrSeatsReassigned),
+		errors.Is(err, services.ErrNoSeatsAvailable):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseID reads the numeric :id path parameter, writing a 400 response when
+// it is not a valid ID.
+func parseID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(ctx.Request.Context(), "invalid id"))
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"VSA_GOGIN_BE/services"
+)
+
+// seatMapSymbols marks each seat status in ASCII seat maps.
+var seatMapSymbols = map[string]byte{
+	services.SeatFree:     '.',
+	services.SeatAssigned: 'x',
+	services.SeatBlocked:  '#',
+}
+
+// seatMapColors fills each seat status in SVG seat maps.
+var seatMapColors = map[string]string{
+	services.SeatFree:     "#d8f3dc",
+	services.SeatAssigned: "#f4a261",
+	services.SeatBlocked:  "#adb5bd",
+}
+
+// renderSeatMapASCII draws the seat map as text, one line per row with a gap
+// for each aisle:
+//
+//	Row  ABC DEF
+//	1    .x. ..#
+//	2    ... x..
+func renderSeatMapASCII(seatMap *services.SeatMap) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %s (%s)\n\n", seatMap.FlightNumber, seatMap.FlightDate, seatMap.Aircraft.AircraftType, seatMap.Aircraft.AircraftTypeKey)
+	fmt.Fprintf(&b, "Row  %s\n", strings.Join(seatMap.Groups, " "))
+
+	for i, row := range seatMap.Rows {
+		fmt.Fprintf(&b, "%-3d  ", i+1)
+		seat := 0
+		for g, group := range seatMap.Groups {
+			if g > 0 {
+				b.WriteByte(' ')
+			}
+			for range group {
+				b.WriteByte(seatMapSymbols[row[seat].Status])
+				seat++
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	fmt.Fprintf(&b, "\n. free (%d)  x assigned (%d)  # blocked (%d)\n",
+		seatMap.Count(services.SeatFree), seatMap.Count(services.SeatAssigned), seatMap.Count(services.SeatBlocked))
+	return b.String()
+}
+
+const (
+	svgSeatSize  = 24
+	svgSeatGap   = 4
+	svgAisleGap  = 20
+	svgMarginTop = 48
+	svgMarginX   = 36
+)
+
+// renderSeatMapSVG draws the seat map as an SVG image with the rows running
+// top to bottom. Hovering a seat shows its number and status.
+func renderSeatMapSVG(seatMap *services.SeatMap) string {
+	// Work out the x offset of each seat letter once, leaving room for aisles.
+	offsets := make([]int, 0, len(seatMap.Aircraft.SeatLetters()))
+	x := svgMarginX
+	for g, group := range seatMap.Groups {
+		if g > 0 {
+			x += svgAisleGap
+		}
+		for range group {
+			offsets = append(offsets, x)
+			x += svgSeatSize + svgSeatGap
+		}
+	}
+	width := x - svgSeatGap + svgMarginX
+	height := svgMarginTop + len(seatMap.Rows)*(svgSeatSize+svgSeatGap) + svgMarginX
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="11">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `<text x="%d" y="16" font-size="13" font-weight="bold">%s %s %s</text>`+"\n",
+		svgMarginX, html.EscapeString(seatMap.FlightNumber), seatMap.FlightDate, html.EscapeString(seatMap.Aircraft.AircraftType))
+
+	for i, letter := range seatMap.Aircraft.SeatLetters() {
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle">%c</text>`+"\n", offsets[i]+svgSeatSize/2, svgMarginTop-8, letter)
+	}
+	for r, row := range seatMap.Rows {
+		y := svgMarginTop + r*(svgSeatSize+svgSeatGap)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%d</text>`+"\n", svgMarginX-8, y+svgSeatSize/2+4, r+1)
+		for i, seat := range row {
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" rx="4" fill="%s" stroke="#495057"><title>%s %s</title></rect>`+"\n",
+				offsets[i], y, svgSeatSize, svgSeatSize, seatMapColors[seat.Status], seat.Seat, seat.Status)
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
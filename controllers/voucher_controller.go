@@ -1,32 +1,20 @@
 package controllers
 
 import (
+	"VSA_GOGIN_BE/dto"
 	"VSA_GOGIN_BE/models"
 	"VSA_GOGIN_BE/services"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
-	"gorm.io/gorm"
 )
 
 type VoucherController struct {
-	DB      *gorm.DB
-	RDB     *redis.Client // Add Redis client
 	Service *services.VoucherService
 }
 
-func NewVoucherController(db *gorm.DB, rdb *redis.Client) *VoucherController {
-	service := &services.VoucherService{
-		DB:  db,
-		RDB: rdb,
-	}
-
-	return &VoucherController{
-		DB:      db,
-		RDB:     rdb,
-		Service: service,
-	}
+func NewVoucherController(service *services.VoucherService) *VoucherController {
+	return &VoucherController{Service: service}
 }
 
 // ListVouchers godoc
@@ -34,69 +22,209 @@ func NewVoucherController(db *gorm.DB, rdb *redis.Client) *VoucherController {
 // @Description Get a list of all vouchers with their associated flight information
 // @Tags vouchers
 // @Produce json
-// @Success 200 {array} models.Voucher "Successfully retrieved vouchers list"
-// @Failure 500 {object} map[string]string "Server error"
+// @Param include_deleted query bool false "Include cancelled (soft-deleted) vouchers"
+// @Success 200 {array} dto.VoucherResponse "Successfully retrieved vouchers list"
+// @Failure 500 {object} dto.ErrorResponse "Server error"
 // @Router /vouchers [get]
 func (c *VoucherController) ListVouchers(ctx *gin.Context) {
-	var vouchers []models.Voucher
-	if err := c.DB.Find(&vouchers).Error; err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var query dto.ListQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+
+	vouchers, err := c.Service.ListVouchers(ctx.Request.Context(), query.IncludeDeleted)
+	if err != nil {
+		respondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, vouchers)
+	ctx.JSON(http.StatusOK, dto.NewVoucherResponses(vouchers))
 }
 
 // Check voucher godoc
 // @Summary Check voucher seat
-// @Description Generate voucher seat for crew members based on the flight ID and flight date and return exist true/false
+// @Description Check whether a voucher was already generated for the crew member on the flight and date, returning its seats, creation time and status when it exists
 // @Tags vouchers
 // @Produce json
-// @Param request body map[string]interface{} true "Voucher generate request"
-// @Success 200 {object} map[string]interface{} "List voucher seat"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 500 {object} map[string]string "Server Error"
+// @Param request body dto.VoucherCheckRequest true "Voucher check request"
+// @Success 200 {object} dto.VoucherCheckResponse "Voucher existence and details"
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 500 {object} dto.ErrorResponse "Server Error"
 // @Router /vouchers/check [post]
 func (c *VoucherController) CheckVoucherSeat(ctx *gin.Context) {
-	var voucher models.Voucher
-	if err := ctx.ShouldBindJSON(&voucher); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req dto.VoucherCheckRequest
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	voucher, err := req.ToModel()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(ctx.Request.Context(), err.Error()))
 		return
 	}
 
-	exists, err := c.Service.CheckVoucherExists(&voucher)
+	existing, err := c.Service.FindVoucher(ctx.Request.Context(), &voucher)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"exists": exists})
+	ctx.JSON(http.StatusOK, dto.NewVoucherCheckResponse(existing))
+}
+
+// CheckVoucherSeats godoc
+// @Summary Check voucher seats in bulk
+// @Description Check up to 100 crew member and flight combinations at once and return each existing voucher
+// @Tags vouchers
+// @Accept json
+// @Produce json
+// @Param request body dto.VoucherCheckBatchRequest true "Voucher check requests"
+// @Success 200 {object} dto.VoucherCheckBatchResponse "Results in request order"
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 500 {object} dto.ErrorResponse "Server Error"
+// @Router /vouchers/check/batch [post]
+func (c *VoucherController) CheckVoucherSeats(ctx *gin.Context) {
+	var req dto.VoucherCheckBatchRequest
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	lookups := make([]models.Voucher, 0, len(req.Items))
+	for _, item := range req.Items {
+		lookup, err := item.ToModel()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(ctx.Request.Context(), err.Error()))
+			return
+		}
+		lookups = append(lookups, lookup)
+	}
+
+	vouchers, err := c.Service.FindVouchers(ctx.Request.Context(), lookups)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	results := make([]dto.VoucherCheckResult, 0, len(req.Items))
+	for i, item := range req.Items {
+		results = append(results, dto.VoucherCheckResult{
+			VoucherCheckRequest:  item,
+			VoucherCheckResponse: dto.NewVoucherCheckResponse(vouchers[i]),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, dto.VoucherCheckBatchResponse{Results: results})
 }
 
 // @Summary Generate voucher seats
 // @Description Generate voucher seat for crew members based on the flight ID and flight date
 // @Tags vouchers
 // @Produce json
-// @Param request body map[string]interface{} true "Voucher generate request"
-// @Success 201 {object} map[string]interface{} "Example: {\"success\": true, \"seats\": [\"3B\", \"7C\", \"14D\"]}"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 500 {object} map[string]string "Server Error"
+// @Param X-API-Key header string false "Client API key; rate limited separately from the client IP"
+// @Param Idempotency-Key header string false "Client-generated key; retries from the same client with the same key return the original response"
+// @Param request body dto.VoucherGenerateRequest true "Voucher generate request"
+// @Success 200 {object} dto.VoucherGenerateResponse "Assigned seats"
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 404 {object} dto.ErrorResponse "Aircraft not found"
+// @Failure 409 {object} dto.ErrorResponse "Voucher already generated, no seats left, or a request with the same Idempotency-Key in progress"
+// @Failure 413 {object} dto.ErrorResponse "Request body larger than 1 MiB"
+// @Failure 422 {object} dto.ErrorResponse "Idempotency-Key reused with a different body"
+// @Failure 429 {object} dto.ErrorResponse "Rate limit exceeded per IP, API key or crew ID; see Retry-After"
+// @Failure 500 {object} dto.ErrorResponse "Server Error"
 // @Router /vouchers/generate [post]
 func (c *VoucherController) GenerateVoucherSeat(ctx *gin.Context) {
-	var voucher models.Voucher
-	if err := ctx.ShouldBindJSON(&voucher); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req dto.VoucherGenerateRequest
+	if !bindJSON(ctx, &req) {
 		return
 	}
 
-	seats, err := c.Service.GenerateVoucherSeats(&voucher)
+	voucher, err := req.ToModel()
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, dto.NewErrorResponse(ctx.Request.Context(), err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"seats":   seats,
+	seats, err := c.Service.GenerateVoucherSeats(ctx.Request.Context(), &voucher)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.VoucherGenerateResponse{
+		Success:       true,
+		Seats:         seats,
+		SeatPositions: dto.NewSeatPositions(seats, voucher.Aircraft),
 	})
 }
+
+// DeleteVoucher godoc
+// @Summary Cancel a voucher
+// @Description Soft-delete a voucher by ID. Its seats become available again.
+// @Tags vouchers
+// @Param id path int true "Voucher ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} dto.ErrorResponse "Voucher not found"
+// @Router /vouchers/{id} [delete]
+func (c *VoucherController) DeleteVoucher(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.Service.DeleteVoucher(ctx.Request.Context(), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RegenerateVoucherSeats godoc
+// @Summary Regenerate voucher seats
+// @Description Give up the seats of a voucher and pick new ones from the seats no other voucher on the flight holds. The new seats may include old ones.
+// @Tags vouchers
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Success 200 {object} dto.VoucherResponse
+// @Failure 404 {object} dto.ErrorResponse "Voucher or its aircraft not found"
+// @Failure 409 {object} dto.ErrorResponse "No seats available"
+// @Router /vouchers/{id}/regenerate [post]
+func (c *VoucherController) RegenerateVoucherSeats(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	voucher, err := c.Service.RegenerateVoucherSeats(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewVoucherResponse(*voucher))
+}
+
+// RestoreVoucher godoc
+// @Summary Restore a cancelled voucher
+// @Description Restore a soft-deleted voucher by ID, provided its seats are still free
+// @Tags vouchers
+// @Produce json
+// @Param id path int true "Voucher ID"
+// @Success 200 {object} dto.VoucherResponse
+// @Failure 404 {object} dto.ErrorResponse "Voucher not found"
+// @Failure 409 {object} dto.ErrorResponse "Voucher or seats taken in the meantime"
+// @Router /vouchers/{id}/restore [post]
+func (c *VoucherController) RestoreVoucher(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	voucher, err := c.Service.RestoreVoucher(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewVoucherResponse(*voucher))
+}
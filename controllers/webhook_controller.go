@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"net/http"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultDeliveryLimit caps the delivery log when the caller sets no limit.
+const defaultDeliveryLimit = 100
+
+type WebhookController struct {
+	Service *services.WebhookService
+}
+
+func NewWebhookController(service *services.WebhookService) *WebhookController {
+	return &WebhookController{Service: service}
+}
+
+// CreateSubscription godoc
+// @Summary Subscribe to voucher events
+// @Description Register a URL to receive the given voucher events. Outside development the URL must use https and must not point to a loopback or private address. Each delivery is POSTed as JSON and signed in the X-VSA-Signature header as "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">", keyed with the secret returned here and never again.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body dto.WebhookSubscriptionRequest true "Subscription details"
+// @Success 201 {object} dto.WebhookSubscriptionCreatedResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Router /webhooks [post]
+func (c *WebhookController) CreateSubscription(ctx *gin.Context) {
+	var req dto.WebhookSubscriptionRequest
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	subscription := req.ToModel()
+	if err := c.Service.CreateSubscription(ctx.Request.Context(), &subscription); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.WebhookSubscriptionCreatedResponse{
+		WebhookSubscriptionResponse: dto.NewWebhookSubscriptionResponse(subscription),
+		Secret:                      subscription.Secret,
+	})
+}
+
+// ListSubscriptions godoc
+// @Summary List webhook subscriptions
+// @Description Get every webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} dto.WebhookSubscriptionResponse
+// @Router /webhooks [get]
+func (c *WebhookController) ListSubscriptions(ctx *gin.Context) {
+	subscriptions, err := c.Service.ListSubscriptions(ctx.Request.Context())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookSubscriptionResponses(subscriptions))
+}
+
+// GetSubscription godoc
+// @Summary Get a webhook subscription by ID
+// @Description Get webhook subscription details by ID
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} dto.WebhookSubscriptionResponse
+// @Failure 404 {object} dto.ErrorResponse "Subscription not found"
+// @Router /webhooks/{id} [get]
+func (c *WebhookController) GetSubscription(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	subscription, err := c.Service.GetSubscription(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookSubscriptionResponse(*subscription))
+}
+
+// UpdateSubscription godoc
+// @Summary Update a webhook subscription
+// @Description Change the URL, events, description or active flag of a subscription. The secret stays the same.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path int true "Subscription ID"
+// @Param request body dto.WebhookSubscriptionRequest true "Subscription details"
+// @Success 200 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Failure 404 {object} dto.ErrorResponse "Subscription not found"
+// @Router /webhooks/{id} [put]
+func (c *WebhookController) UpdateSubscription(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	subscription, err := c.Service.GetSubscription(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	var req dto.WebhookSubscriptionRequest
+	if !bindJSON(ctx, &req) {
+		return
+	}
+
+	req.ApplyTo(subscription)
+	if err := c.Service.UpdateSubscription(ctx.Request.Context(), subscription); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookSubscriptionResponse(*subscription))
+}
+
+// DeleteSubscription godoc
+// @Summary Delete a webhook subscription
+// @Description Soft-delete a subscription by ID. Its pending deliveries are dead-lettered.
+// @Tags webhooks
+// @Param id path int true "Subscription ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} dto.ErrorResponse "Subscription not found"
+// @Router /webhooks/{id} [delete]
+func (c *WebhookController) DeleteSubscription(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.Service.DeleteSubscription(ctx.Request.Context(), id); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Query the delivery log, newest first. Deliveries that failed every attempt have the dead_letter status.
+// @Tags webhooks
+// @Produce json
+// @Param subscription_id query int false "Subscription ID"
+// @Param event query string false "Event type" Enums(voucher.issued, voucher.regenerated, voucher.cancelled, voucher.restored)
+// @Param status query string false "Delivery status" Enums(pending, succeeded, dead_letter)
+// @Param limit query int false "Maximum number of deliveries (default 100, max 1000)"
+// @Param offset query int false "Number of deliveries to skip"
+// @Success 200 {array} dto.WebhookDeliveryResponse
+// @Failure 400 {object} dto.ValidationErrorResponse "Validation error"
+// @Router /webhooks/deliveries [get]
+func (c *WebhookController) ListDeliveries(ctx *gin.Context) {
+	var query dto.WebhookDeliveryQuery
+	if !bindQuery(ctx, &query) {
+		return
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultDeliveryLimit
+	}
+
+	deliveries, err := c.Service.ListDeliveries(ctx.Request.Context(), query.ToFilter())
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookDeliveryResponses(deliveries))
+}
+
+// GetDelivery godoc
+// @Summary Get a webhook delivery by ID
+// @Description Get a delivery with its payload and every attempt made so far
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} dto.WebhookDeliveryDetailResponse
+// @Failure 404 {object} dto.ErrorResponse "Delivery not found"
+// @Router /webhooks/deliveries/{id} [get]
+func (c *WebhookController) GetDelivery(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	delivery, err := c.Service.GetDelivery(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookDeliveryDetailResponse(*delivery))
+}
+
+// RetryDelivery godoc
+// @Summary Retry a webhook delivery
+// @Description Queue a dead-lettered or delivered event for a new round of attempts with the same event ID
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 202 {object} dto.WebhookDeliveryResponse
+// @Failure 404 {object} dto.ErrorResponse "Delivery not found"
+// @Failure 409 {object} dto.ErrorResponse "Delivery still pending"
+// @Router /webhooks/deliveries/{id}/retry [post]
+func (c *WebhookController) RetryDelivery(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	delivery, err := c.Service.RetryDelivery(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, dto.NewWebhookDeliveryResponse(*delivery))
+}
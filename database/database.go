@@ -0,0 +1,300 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// slowQueryThreshold is how long a statement may take before it is logged
+// as slow.
+const slowQueryThreshold = 200 * time.Millisecond
+
+// busyTimeoutMs is how long SQLite waits for a competing writer to release
+// its lock before failing with SQLITE_BUSY.
+const busyTimeoutMs = 5000
+
+// Open connects to the SQLite database at path with foreign key enforcement
+// turned on, which SQLite leaves off by default. Transactions take the write
+// lock when they begin (BEGIN IMMEDIATE), so two transactions cannot both
+// read a flight's seats and then write conflicting vouchers.
+func Open(path string) (*gorm.DB, error) {
+	dsn := fmt.Sprintf("%s?_foreign_keys=on&_txlock=immediate&_busy_timeout=%d", path, busyTimeoutMs)
+	return gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logging.NewGormLogger(slowQueryThreshold),
+	})
+}
+
+// VoucherUniqueIndex allows one active voucher per crew member, flight and
+// date. Cancelled vouchers are left out so the voucher can be generated again.
+const VoucherUniqueIndex = "idx_vouchers_crew_flight"
+
+// reportIndexes back the report queries, which aggregate active vouchers by
+// flight date range and by crew base.
+var reportIndexes = []struct{ Name, Columns string }{
+	{"idx_vouchers_flight_date", "flight_date, flight_number"},
+	{"idx_vouchers_crew_base", "crew_base, flight_date"},
+}
+
+// ErrDuplicateVouchers is returned by Migrate when existing vouchers break
+// the rule VoucherUniqueIndex enforces.
+var ErrDuplicateVouchers = errors.New("crew members hold several vouchers for the same flight")
+
+// ErrMigrationPending is returned by CheckSchema when the database lags
+// behind the models.
+var ErrMigrationPending = errors.New("database migration pending")
+
+// schema lists the models Migrate keeps in sync with the database.
+var schema = []interface{}{
+	&models.Aircraft{}, &models.Voucher{}, &models.ArchivedVoucher{}, &models.AuditLog{},
+	&models.WebhookSubscription{}, &models.WebhookDelivery{}, &models.WebhookAttempt{},
+	&models.OutboxEvent{},
+}
+
+// Migrate brings the schema up to date and backfills columns added to
+// existing tables.
+func Migrate(db *gorm.DB) error {
+	if err := db.AutoMigrate(schema...); err != nil {
+		return err
+	}
+
+	if err := backfillVoucherAircraftIDs(db); err != nil {
+		return err
+	}
+	if err := backfillBlockedSeats(db); err != nil {
+		return err
+	}
+	if err := addAisleMarkers(db); err != nil {
+		return err
+	}
+	if err := createReportIndexes(db); err != nil {
+		return err
+	}
+
+	// Normalized dates can make vouchers collide, so they are checked for
+	// duplicates in the same transaction. A failed check leaves the old
+	// dates in place.
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := normalizeFlightDates(tx); err != nil {
+			return err
+		}
+		return createVoucherUniqueIndex(tx)
+	})
+}
+
+func createReportIndexes(db *gorm.DB) error {
+	for _, index := range reportIndexes {
+		if err := db.Exec("CREATE INDEX IF NOT EXISTS " + index.Name +
+			" ON vouchers (" + index.Columns + ") WHERE deleted_at IS NULL").Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addAisleMarkers rewrites seat layouts stored before aisle markers existed,
+// inferring the aisles from the number of seats per row, e.g. "ABCDEF"
+// becomes "ABC-DEF".
+func addAisleMarkers(db *gorm.DB) error {
+	var aircraft []models.Aircraft
+	if err := db.Unscoped().Where(unmarkedSeatLayout).Find(&aircraft).Error; err != nil {
+		return err
+	}
+	for _, a := range aircraft {
+		layout := models.SeatLayoutFor(a.SeatsPerRow)
+		if err := db.Unscoped().Model(&a).Update("seats_per_row", layout).Error; err != nil {
+			return err
+		}
+		logging.For("database").Info("added aisle markers to seat layout",
+			"aircraft_type_key", a.AircraftTypeKey,
+			"seats_per_row", layout,
+		)
+	}
+	return nil
+}
+
+// unmarkedSeatLayout matches aircraft whose seat layout needs aisle markers.
+// Rows of a single seat have no aisle to mark.
+const unmarkedSeatLayout = "instr(seats_per_row, '-') = 0 AND length(seats_per_row) > 1"
+
+// legacyFlightDate matches flight dates written as timestamps before they
+// became civil dates.
+const legacyFlightDate = "length(flight_date) > 10"
+
+// normalizeFlightDates rewrites flight dates written as timestamps before
+// they became civil dates to the local date at the departure airport, or in
+// the default time zone for vouchers without one. Their date part alone is
+// the UTC date, a day early for flights leaving east of UTC before dawn.
+// The unique index is dropped while vouchers are rewritten; the caller
+// recreates it.
+func normalizeFlightDates(db *gorm.DB) error {
+	for _, table := range []string{"vouchers", "archived_vouchers"} {
+		// The cast keeps the driver from parsing the stored text itself.
+		var rows []struct {
+			ID               uint
+			FlightDate       string
+			DepartureAirport string
+		}
+		if err := db.Table(table).
+			Select("id, CAST(flight_date AS TEXT) AS flight_date, departure_airport").
+			Where(legacyFlightDate).
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			continue
+		}
+		if table == "vouchers" {
+			if err := db.Exec("DROP INDEX IF EXISTS " + VoucherUniqueIndex).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, row := range rows {
+			date, err := legacyFlightDateOf(row.FlightDate, row.DepartureAirport)
+			if err != nil {
+				return fmt.Errorf("failed to normalize flight date of %s %d: %w", table, row.ID, err)
+			}
+			if err := db.Table(table).Where("id = ?", row.ID).Update("flight_date", date).Error; err != nil {
+				return err
+			}
+		}
+		logging.For("database").Info("normalized flight dates", "table", table, "rows", len(rows))
+	}
+	return nil
+}
+
+// legacyFlightDateOf converts a flight date stored as a timestamp. Airports
+// whose time zone is unknown fall back to the default time zone.
+func legacyFlightDateOf(value, departureAirport string) (models.Date, error) {
+	t, err := models.ParseTimestamp(value)
+	if err != nil {
+		return models.Date{}, err
+	}
+	if !models.KnownAirport(departureAirport) {
+		departureAirport = ""
+	}
+	return models.FlightDateAt(t, departureAirport)
+}
+
+// createVoucherUniqueIndex adds VoucherUniqueIndex once no crew member holds
+// several active vouchers for a flight. Duplicates are logged one by one and
+// fail the migration, since picking which voucher to cancel is up to ops.
+func createVoucherUniqueIndex(db *gorm.DB) error {
+	var duplicates []struct {
+		CrewID       string
+		FlightNumber string
+		FlightDate   models.Date
+		IDs          string
+	}
+	if err := db.Raw(`SELECT crew_id, flight_number, flight_date, GROUP_CONCAT(id) AS ids
+		FROM vouchers WHERE deleted_at IS NULL
+		GROUP BY crew_id, flight_number, flight_date HAVING COUNT(*) > 1`).Scan(&duplicates).Error; err != nil {
+		return err
+	}
+	for _, d := range duplicates {
+		logging.For("database").Error("duplicate vouchers",
+			"crew_id", d.CrewID,
+			"flight_number", d.FlightNumber,
+			"flight_date", d.FlightDate.String(),
+			"voucher_ids", d.IDs,
+		)
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("%w: %d crew member and flight pairs affected; cancel all but one voucher of each and migrate again", ErrDuplicateVouchers, len(duplicates))
+	}
+
+	return db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS " + VoucherUniqueIndex +
+		" ON vouchers (crew_id, flight_number, flight_date) WHERE deleted_at IS NULL").Error
+}
+
+// backfillVoucherAircraftIDs links vouchers created before aircraft_id existed
+// to their aircraft through the aircraft type key they were issued with.
+func backfillVoucherAircraftIDs(db *gorm.DB) error {
+	result := db.Exec(`UPDATE vouchers SET aircraft_id = (
+		SELECT aircrafts.id FROM aircrafts WHERE aircrafts.aircraft_type_key = vouchers.aircraft_type_key
+	) WHERE aircraft_id IS NULL AND aircraft_type_key IN (SELECT aircraft_type_key FROM aircrafts)`)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		logging.For("database").Info("linked vouchers to their aircraft", "vouchers", result.RowsAffected)
+	}
+
+	var orphans int64
+	if err := db.Model(&models.Voucher{}).Where("aircraft_id IS NULL").Count(&orphans).Error; err != nil {
+		return err
+	}
+	if orphans > 0 {
+		logging.For("database").Warn("vouchers reference an aircraft type key that no longer exists", "vouchers", orphans)
+	}
+	return nil
+}
+
+// backfillBlockedSeats clears the NULL blocked seats of aircraft created
+// before the column existed, so reports computing capacity in SQL see no
+// blocked seats rather than NULL.
+func backfillBlockedSeats(db *gorm.DB) error {
+	result := db.Exec("UPDATE aircrafts SET blocked_seats = '' WHERE blocked_seats IS NULL")
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		logging.For("database").Info("backfilled blocked seats", "aircraft", result.RowsAffected)
+	}
+	return nil
+}
+
+// CheckSchema reports ErrMigrationPending when a table, column or index is
+// missing from the database, e.g. because Migrate has not run yet, or when
+// data Migrate rewrites is still in its old shape.
+func CheckSchema(ctx context.Context, db *gorm.DB) error {
+	db = db.WithContext(ctx)
+	migrator := db.Migrator()
+	for _, model := range schema {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		if !migrator.HasTable(model) {
+			return fmt.Errorf("%w: table %s is missing", ErrMigrationPending, stmt.Schema.Table)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !migrator.HasColumn(model, field.DBName) {
+				return fmt.Errorf("%w: column %s.%s is missing", ErrMigrationPending, stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+	if !migrator.HasIndex(&models.Voucher{}, VoucherUniqueIndex) {
+		return fmt.Errorf("%w: index %s is missing", ErrMigrationPending, VoucherUniqueIndex)
+	}
+	for _, index := range reportIndexes {
+		if !migrator.HasIndex(&models.Voucher{}, index.Name) {
+			return fmt.Errorf("%w: index %s is missing", ErrMigrationPending, index.Name)
+		}
+	}
+	for _, model := range []interface{}{&models.Voucher{}, &models.ArchivedVoucher{}} {
+		var legacy int64
+		if err := db.Model(model).Unscoped().Where(legacyFlightDate).Count(&legacy).Error; err != nil {
+			return err
+		}
+		if legacy > 0 {
+			return fmt.Errorf("%w: %d flight dates are stored as timestamps", ErrMigrationPending, legacy)
+		}
+	}
+	var unmarked int64
+	if err := db.Model(&models.Aircraft{}).Unscoped().Where(unmarkedSeatLayout).Count(&unmarked).Error; err != nil {
+		return err
+	}
+	if unmarked > 0 {
+		return fmt.Errorf("%w: %d aircraft seat layouts lack aisle markers", ErrMigrationPending, unmarked)
+	}
+	return ctx.Err()
+}
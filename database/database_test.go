@@ -0,0 +1,121 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"VSA_GOGIN_BE/models"
+
+	"gorm.io/gorm"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := Open(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// insertLegacyVoucher writes a voucher whose flight date is stored as a
+// timestamp, as rows written before flight dates became civil dates are.
+func insertLegacyVoucher(t *testing.T, db *gorm.DB, id uint, crewID, flightDate, airport string) {
+	t.Helper()
+	if err := db.Exec(`INSERT INTO vouchers (id, crew_id, flight_number, flight_date, departure_airport, aircraft_type_key, seat1)
+		VALUES (?, ?, 'GA123', ?, ?, 'atr_72', '1A')`, id, crewID, flightDate, airport).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func storedFlightDate(t *testing.T, db *gorm.DB, id uint) string {
+	t.Helper()
+	var value string
+	if err := db.Raw("SELECT CAST(flight_date AS TEXT) FROM vouchers WHERE id = ?", id).Scan(&value).Error; err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestMigrateNormalizesLegacyFlightDates(t *testing.T) {
+	db := openTestDB(t)
+	// The unique index is in place from the first migration; the rewritten
+	// dates must not trip over it.
+	insertLegacyVoucher(t, db, 1, "A1", "2025-11-30 17:00:00+00:00", "CGK")
+	insertLegacyVoucher(t, db, 2, "A2", "2025-11-30 16:59:59+00:00", "")
+	insertLegacyVoucher(t, db, 3, "A3", "2025-11-30T16:30:00Z", "DPS")
+	insertLegacyVoucher(t, db, 4, "A4", "2025-03-09 04:30:00+00:00", "JFK")
+	insertLegacyVoucher(t, db, 5, "A5", "2025-11-30 17:00:00+00:00", "ZZZ")
+	if err := CheckSchema(t.Context(), db); !errors.Is(err, ErrMigrationPending) {
+		t.Errorf("CheckSchema before migrating = %v, want %v", err, ErrMigrationPending)
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[uint]string{
+		1: "2025-12-01", // Jakarta midnight
+		2: "2025-11-30", // a second before midnight in the default zone
+		3: "2025-12-01", // 00:30 in Bali
+		4: "2025-03-08", // 23:30 EST on the eve of the switch to EDT
+		5: "2025-12-01", // unknown airport, default zone
+	}
+	for id, date := range want {
+		if got := storedFlightDate(t, db, id); got != date {
+			t.Errorf("voucher %d flight date = %s, want %s", id, got, date)
+		}
+	}
+	if err := CheckSchema(t.Context(), db); err != nil {
+		t.Errorf("CheckSchema after migrating = %v", err)
+	}
+}
+
+func TestMigrateRejectsVouchersCollidingAfterNormalizing(t *testing.T) {
+	db := openTestDB(t)
+	insertLegacyVoucher(t, db, 1, "A1", "2025-12-01", "CGK")
+	insertLegacyVoucher(t, db, 2, "A1", "2025-11-30 17:00:00+00:00", "CGK")
+
+	if err := Migrate(db); !errors.Is(err, ErrDuplicateVouchers) {
+		t.Fatalf("Migrate = %v, want %v", err, ErrDuplicateVouchers)
+	}
+
+	// The failed migration leaves the dates as they were.
+	if got := storedFlightDate(t, db, 2); got != "2025-11-30 17:00:00+00:00" {
+		t.Errorf("voucher 2 flight date = %s, want it untouched", got)
+	}
+
+	if err := db.Exec("UPDATE vouchers SET deleted_at = CURRENT_TIMESTAMP WHERE id = 2").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate after cancelling the duplicate = %v", err)
+	}
+	if !db.Migrator().HasIndex(&models.Voucher{}, VoucherUniqueIndex) {
+		t.Errorf("index %s is missing", VoucherUniqueIndex)
+	}
+}
+
+func TestMigrateBackfillsBlockedSeats(t *testing.T) {
+	db := openTestDB(t)
+	// The column is added to existing aircraft as NULL.
+	if err := db.Exec(`INSERT INTO aircrafts (id, aircraft_type, aircraft_type_key, num_rows, seats_per_row, blocked_seats)
+		VALUES (1, 'ATR 72', 'atr_72', 18, 'AB-CD', NULL)`).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	var nulls int64
+	if err := db.Raw("SELECT COUNT(*) FROM aircrafts WHERE blocked_seats IS NULL").Scan(&nulls).Error; err != nil {
+		t.Fatal(err)
+	}
+	if nulls != 0 {
+		t.Errorf("%d aircraft still have NULL blocked seats", nulls)
+	}
+}
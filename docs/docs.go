@@ -25,13 +25,21 @@ const docTemplate = `{
                     "aircraft"
                 ],
                 "summary": "List all aircraft",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted aircraft",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Aircraft"
+                                "$ref": "#/definitions/dto.AircraftResponse"
                             }
                         }
                     }
@@ -49,11 +57,34 @@ const docTemplate = `{
                     "aircraft"
                 ],
                 "summary": "Create a new aircraft",
+                "parameters": [
+                    {
+                        "description": "Aircraft details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AircraftRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Aircraft"
+                            "$ref": "#/definitions/dto.AircraftResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Aircraft type already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
@@ -82,7 +113,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Aircraft"
+                            "$ref": "#/definitions/dto.AircraftResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Aircraft not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
@@ -106,19 +143,46 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Aircraft details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AircraftRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Aircraft"
+                            "$ref": "#/definitions/dto.AircraftResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Aircraft not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Aircraft type already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             },
             "delete": {
-                "description": "Delete aircraft by ID",
+                "description": "Soft-delete aircraft by ID. Depending on the delete policy, aircraft still referenced by vouchers are either kept or deleted together with their vouchers.",
                 "tags": [
                     "aircraft"
                 ],
@@ -135,201 +199,2484 @@ const docTemplate = `{
                 "responses": {
                     "204": {
                         "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Aircraft not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Aircraft referenced by vouchers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/vouchers": {
+        "/aircraft/{id}/restore": {
+            "post": {
+                "description": "Restore a soft-deleted aircraft by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aircraft"
+                ],
+                "summary": "Restore a deleted aircraft",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Aircraft ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AircraftResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Aircraft not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/audit": {
             "get": {
-                "description": "Get a list of all vouchers with their associated flight information",
+                "description": "Query the append-only audit log of aircraft, voucher and webhook subscription changes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "vouchers"
+                    "audit"
+                ],
+                "summary": "List audit log entries",
+                "parameters": [
+                    {
+                        "enum": [
+                            "aircraft",
+                            "voucher",
+                            "webhook"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore",
+                            "generate",
+                            "regenerate"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List all vouchers",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved vouchers list",
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Voucher"
+                                "$ref": "#/definitions/dto.AuditLogResponse"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Server error",
+                    "400": {
+                        "description": "Validation error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vouchers/check": {
-            "post": {
-                "description": "Generate voucher seat for crew members based on the flight ID and flight date and return exist true/false",
+        "/audit/export": {
+            "get": {
+                "description": "Download every audit log entry matching the filters as JSON or CSV for compliance reviews",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "vouchers"
+                    "audit"
                 ],
-                "summary": "Check voucher seat",
+                "summary": "Export audit log entries",
                 "parameters": [
                     {
-                        "description": "Voucher generate request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "aircraft",
+                            "voucher",
+                            "webhook"
+                        ],
+                        "type": "string",
+                        "description": "Entity type",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Actor",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete",
+                            "restore",
+                            "generate",
+                            "regenerate"
+                        ],
+                        "type": "string",
+                        "description": "Action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List voucher seat",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AuditLogResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Validation error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Page through the voucher and aircraft change events relayed to the Redis stream, oldest first, without involving a consumer group",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Read the event stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Stream ID of the last entry already read; empty starts at the beginning",
+                        "name": "after",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Server Error",
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.EventStreamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid offset",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/vouchers/generate": {
+        "/events/groups/{group}/replay": {
             "post": {
-                "description": "Generate voucher seat for crew members based on the flight ID and flight date",
-                "produces": [
+                "description": "Move the consumer group back (or forward) so that its consumers read every entry after the given stream ID again. Entries read but not acknowledged stay pending.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "vouchers"
+                    "events"
                 ],
-                "summary": "Generate voucher seats",
+                "summary": "Replay the event stream to a consumer group",
                 "parameters": [
                     {
-                        "description": "Voucher generate request",
+                        "type": "string",
+                        "description": "Consumer group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Offset to replay from",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.EventReplayRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Example: {\\\"success\\\": true, \\\"seats\\\": [\\\"3B\\\", \\\"7C\\\", \\\"14D\\\"]}",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid offset",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Consumer group not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "description": "Get the length of the event stream, how far each consumer group has read it and how many outbox events wait to be relayed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Describe the event stream",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.EventStreamInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/flights/{number}/{date}/seatmap": {
+            "get": {
+                "description": "Get the cabin layout of a flight with each seat's position and status: free, assigned to a voucher or blocked. The aircraft is the one the flight's vouchers were issued for unless aircraft_type_key is given. Set format to ascii or svg for a rendering ops can read at a glance.",
+                "produces": [
+                    "application/json",
+                    "text/plain",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "flights"
+                ],
+                "summary": "Get flight seat map",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "ID001",
+                        "description": "Flight number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-12-01",
+                        "description": "Local departure date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Departure airport IATA code, used to convert timestamps",
+                        "name": "departure_airport",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aircraft type key, required when the flight has no vouchers",
+                        "name": "aircraft_type_key",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "ascii",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.SeatMapResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Validation error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Flight or aircraft not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Server Error",
+                        "description": "Server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Aircraft": {
-            "type": "object",
-            "properties": {
-                "aircraft_type": {
-                    "type": "string"
-                },
-                "aircraft_type_key": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "num_rows": {
-                    "type": "integer"
-                },
-                "seats_per_row": {
-                    "description": "Comma-separated seat letters, e.g. \"A,C,D,F\"",
-                    "type": "string"
-                }
-            }
         },
-        "models.Voucher": {
-            "type": "object",
+        "/reports/aircraft-types": {
+            "get": {
+                "description": "Flights, vouchers and fill rate of every aircraft type over its flights with active vouchers, ordered by aircraft type key.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report seat utilization per aircraft type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First flight date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last flight date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aircraft type key",
+                        "name": "aircraft_type_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crew base IATA code",
+                        "name": "crew_base",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.AircraftTypeReportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/crew": {
+            "get": {
+                "description": "Vouchers, flights and seats per crew member with their first and last flight, busiest first",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report voucher usage per crew member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First flight date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last flight date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aircraft type key",
+                        "name": "aircraft_type_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crew base IATA code",
+                        "name": "crew_base",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CrewReportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/crew-bases": {
+            "get": {
+                "description": "Vouchers, crew members, flights and seats per crew base, busiest first. Vouchers issued without a crew base are grouped under an empty crew_base.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report voucher usage per crew base",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First flight date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last flight date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aircraft type key",
+                        "name": "aircraft_type_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crew base IATA code",
+                        "name": "crew_base",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CrewBaseReportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/flights": {
+            "get": {
+                "description": "Seats assigned, seats remaining and fill rate of every flight with active vouchers, ordered by flight date. Blocked seats do not count towards capacity.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report seat utilization per flight",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First flight date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last flight date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Aircraft type key",
+                        "name": "aircraft_type_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Crew base IATA code",
+                        "name": "crew_base",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format (default json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rows to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.FlightReportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers": {
+            "get": {
+                "description": "Get a list of all vouchers with their associated flight information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "List all vouchers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include cancelled (soft-deleted) vouchers",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved vouchers list",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.VoucherResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/check": {
+            "post": {
+                "description": "Check whether a voucher was already generated for the crew member on the flight and date, returning its seats, creation time and status when it exists",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Check voucher seat",
+                "parameters": [
+                    {
+                        "description": "Voucher check request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherCheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Voucher existence and details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherCheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/check/batch": {
+            "post": {
+                "description": "Check up to 100 crew member and flight combinations at once and return each existing voucher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Check voucher seats in bulk",
+                "parameters": [
+                    {
+                        "description": "Voucher check requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherCheckBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Results in request order",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherCheckBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/generate": {
+            "post": {
+                "description": "Generate voucher seat for crew members based on the flight ID and flight date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Generate voucher seats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client API key; rate limited separately from the client IP",
+                        "name": "X-API-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries from the same client with the same key return the original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Voucher generate request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherGenerateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assigned seats",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherGenerateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Aircraft not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Voucher already generated, no seats left, or a request with the same Idempotency-Key in progress",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body larger than 1 MiB",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency-Key reused with a different body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded per IP, API key or crew ID; see Retry-After",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}": {
+            "delete": {
+                "description": "Soft-delete a voucher by ID. Its seats become available again.",
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Cancel a voucher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Voucher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Voucher not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}/regenerate": {
+            "post": {
+                "description": "Give up the seats of a voucher and pick new ones from the seats no other voucher on the flight holds. The new seats may include old ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Regenerate voucher seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Voucher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voucher or its aircraft not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "No seats available",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}/restore": {
+            "post": {
+                "description": "Restore a soft-deleted voucher by ID, provided its seats are still free",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Restore a cancelled voucher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Voucher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VoucherResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Voucher not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Voucher or seats taken in the meantime",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "description": "Get every webhook subscription",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookSubscriptionResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a URL to receive the given voucher events. Outside development the URL must use https and must not point to a loopback or private address. Each delivery is POSTed as JSON and signed in the X-VSA-Signature header as \"t=\u003cunix seconds\u003e,v1=\u003chex HMAC-SHA256 of \"\u003ct\u003e.\u003cbody\u003e\"\u003e\", keyed with the secret returned here and never again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Subscribe to voucher events",
+                "parameters": [
+                    {
+                        "description": "Subscription details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookSubscriptionCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries": {
+            "get": {
+                "description": "Query the delivery log, newest first. Deliveries that failed every attempt have the dead_letter status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "voucher.issued",
+                            "voucher.regenerated",
+                            "voucher.cancelled",
+                            "voucher.restored"
+                        ],
+                        "type": "string",
+                        "description": "Event type",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "succeeded",
+                            "dead_letter"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of deliveries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}": {
+            "get": {
+                "description": "Get a delivery with its payload and every attempt made so far",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook delivery by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryDetailResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deliveries/{id}/retry": {
+            "post": {
+                "description": "Queue a dead-lettered or delivered event for a new round of attempts with the same event ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery still pending",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "description": "Get webhook subscription details by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook subscription by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the URL, events, description or active flag of a subscription. The secret stays the same.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WebhookSubscriptionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft-delete a subscription by ID. Its pending deliveries are dead-lettered.",
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AircraftRequest": {
+            "type": "object",
+            "required": [
+                "aircraft_type",
+                "seats_per_row"
+            ],
+            "properties": {
+                "aircraft_type": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "Airbus A320"
+                },
+                "blocked_seats": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "1A",
+                        "1F"
+                    ]
+                },
+                "num_rows": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "example": 32
+                },
+                "seats_per_row": {
+                    "type": "string",
+                    "example": "ABC-DEF"
+                }
+            }
+        },
+        "dto.AircraftResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft_type": {
+                    "type": "string",
+                    "example": "Airbus A320"
+                },
+                "aircraft_type_key": {
+                    "type": "string",
+                    "example": "airbus_a320"
+                },
+                "blocked_seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "1A",
+                        "1F"
+                    ]
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "num_rows": {
+                    "type": "integer",
+                    "example": 32
+                },
+                "seats_per_row": {
+                    "type": "string",
+                    "example": "ABC-DEF"
+                }
+            }
+        },
+        "dto.AircraftTypeReportResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft_type": {
+                    "type": "string",
+                    "example": "ATR 72"
+                },
+                "aircraft_type_key": {
+                    "type": "string",
+                    "example": "atr_72"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 270
+                },
+                "fill_rate": {
+                    "type": "number",
+                    "example": 0.1111
+                },
+                "flights": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "seats_assigned": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "vouchers": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "dto.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "ops@example.com"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "aircraft"
+                },
+                "entity_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "remote_addr": {
+                    "type": "string",
+                    "example": "10.0.0.7"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "seats_chosen": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seats_considered": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ConsumerGroupResponse": {
+            "type": "object",
+            "properties": {
+                "consumers": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "lag": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "last_delivered_id": {
+                    "type": "string",
+                    "example": "1767225600000-0"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "data-platform"
+                },
+                "pending": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.CrewBaseReportResponse": {
+            "type": "object",
+            "properties": {
+                "crew_base": {
+                    "type": "string",
+                    "example": "CGK"
+                },
+                "crew_members": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "flights": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "seats_assigned": {
+                    "type": "integer",
+                    "example": 75
+                },
+                "vouchers": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "dto.CrewReportResponse": {
+            "type": "object",
+            "properties": {
+                "crew_base": {
+                    "type": "string",
+                    "example": "CGK"
+                },
+                "crew_id": {
+                    "type": "string",
+                    "example": "S001"
+                },
+                "crew_name": {
+                    "type": "string",
+                    "example": "Sinta"
+                },
+                "first_flight": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2025-12-01"
+                },
+                "flights": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "last_flight": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2025-12-09"
+                },
+                "seats_assigned": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "vouchers": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Aircraft not found"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f2b9c1e8d4a4f6b9e3c2a1d0b7e6f5a"
+                }
+            }
+        },
+        "dto.EventReplayRequest": {
+            "type": "object",
+            "required": [
+                "from"
+            ],
+            "properties": {
+                "from": {
+                    "description": "From is the stream ID of the last entry the group should count as\nread; \"0\" replays the whole stream.",
+                    "type": "string",
+                    "example": "1767225600000-0"
+                }
+            }
+        },
+        "dto.EventStreamInfoResponse": {
+            "type": "object",
+            "properties": {
+                "backlog": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ConsumerGroupResponse"
+                    }
+                },
+                "length": {
+                    "type": "integer",
+                    "example": 1520
+                },
+                "stream": {
+                    "type": "string",
+                    "example": "vsa:events"
+                }
+            }
+        },
+        "dto.EventStreamResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StreamEventResponse"
+                    }
+                },
+                "next": {
+                    "type": "string",
+                    "example": "1767225600000-0"
+                }
+            }
+        },
+        "dto.FlightReportResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft_type": {
+                    "type": "string",
+                    "example": "ATR 72"
+                },
+                "aircraft_type_key": {
+                    "type": "string",
+                    "example": "atr_72"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "fill_rate": {
+                    "type": "number",
+                    "example": 0.1333
+                },
+                "flight_date": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2025-12-01"
+                },
+                "flight_number": {
+                    "type": "string",
+                    "example": "ID001"
+                },
+                "seats_assigned": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "seats_remaining": {
+                    "type": "integer",
+                    "example": 78
+                },
+                "vouchers": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "dto.SeatMapAisle": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "string",
+                    "example": "C"
+                },
+                "before": {
+                    "type": "string",
+                    "example": "D"
+                }
+            }
+        },
+        "dto.SeatMapColumn": {
+            "type": "object",
+            "properties": {
+                "letter": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "window",
+                        "middle",
+                        "aisle"
+                    ],
+                    "example": "window"
+                }
+            }
+        },
+        "dto.SeatMapResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
+                    "$ref": "#/definitions/dto.AircraftResponse"
+                },
+                "aisles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeatMapAisle"
+                    }
+                },
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeatMapColumn"
+                    }
+                },
+                "flight_date": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2025-12-01"
+                },
+                "flight_number": {
+                    "type": "string",
+                    "example": "ID001"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeatMapRowResponse"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/dto.SeatMapSummary"
+                }
+            }
+        },
+        "dto.SeatMapRowResponse": {
+            "type": "object",
+            "properties": {
+                "row": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeatMapSeatResponse"
+                    }
+                }
+            }
+        },
+        "dto.SeatMapSeatResponse": {
+            "type": "object",
+            "properties": {
+                "letter": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "window",
+                        "middle",
+                        "aisle"
+                    ],
+                    "example": "window"
+                },
+                "seat": {
+                    "type": "string",
+                    "example": "1A"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "free",
+                        "assigned",
+                        "blocked"
+                    ],
+                    "example": "free"
+                },
+                "voucher_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.SeatMapSummary": {
+            "type": "object",
+            "properties": {
+                "assigned": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "blocked": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "free": {
+                    "type": "integer",
+                    "example": 180
+                }
+            }
+        },
+        "dto.SeatPosition": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "string",
+                    "enum": [
+                        "window",
+                        "middle",
+                        "aisle"
+                    ],
+                    "example": "window"
+                },
+                "seat": {
+                    "type": "string",
+                    "example": "1F"
+                }
+            }
+        },
+        "dto.StreamEventResponse": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string",
+                    "example": "evt_9b2f4c0d6a1e4f3b8c7d2e1f0a9b8c7d"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "stream_id": {
+                    "type": "string",
+                    "example": "1767225600000-0"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "voucher.issued"
+                }
+            }
+        },
+        "dto.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "validation failed"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validators.FieldError"
+                    }
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f2b9c1e8d4a4f6b9e3c2a1d0b7e6f5a"
+                }
+            }
+        },
+        "dto.VoucherCheckBatchRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.VoucherCheckRequest"
+                    }
+                }
+            }
+        },
+        "dto.VoucherCheckBatchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VoucherCheckResult"
+                    }
+                }
+            }
+        },
+        "dto.VoucherCheckRequest": {
+            "type": "object",
+            "required": [
+                "crew_id",
+                "flight_date",
+                "flight_number"
+            ],
+            "properties": {
+                "aircraft_type_key": {
+                    "description": "AircraftTypeKey is accepted for compatibility but not matched on: a\ncrew member holds at most one voucher per flight whatever the aircraft.",
+                    "type": "string",
+                    "example": "atr_72"
+                },
+                "crew_id": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "S001"
+                },
+                "departure_airport": {
+                    "type": "string",
+                    "example": "CGK"
+                },
+                "flight_date": {
+                    "type": "string",
+                    "example": "2025-12-01"
+                },
+                "flight_number": {
+                    "type": "string",
+                    "example": "ID001"
+                }
+            }
+        },
+        "dto.VoucherCheckResponse": {
+            "type": "object",
+            "properties": {
+                "exists": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "voucher": {
+                    "$ref": "#/definitions/dto.VoucherResponse"
+                }
+            }
+        },
+        "dto.VoucherCheckResult": {
+            "type": "object",
+            "required": [
+                "crew_id",
+                "flight_date",
+                "flight_number"
+            ],
+            "properties": {
+                "aircraft_type_key": {
+                    "description": "AircraftTypeKey is accepted for compatibility but not matched on: a\ncrew member holds at most one voucher per flight whatever the aircraft.",
+                    "type": "string",
+                    "example": "atr_72"
+                },
+                "crew_id": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "S001"
+                },
+                "departure_airport": {
+                    "type": "string",
+                    "example": "CGK"
+                },
+                "exists": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "flight_date": {
+                    "type": "string",
+                    "example": "2025-12-01"
+                },
+                "flight_number": {
+                    "type": "string",
+                    "example": "ID001"
+                },
+                "voucher": {
+                    "$ref": "#/definitions/dto.VoucherResponse"
+                }
+            }
+        },
+        "dto.VoucherGenerateRequest": {
+            "type": "object",
+            "required": [
+                "aircraft_type_key",
+                "crew_id",
+                "crew_name",
+                "flight_date",
+                "flight_number"
+            ],
+            "properties": {
+                "aircraft_type_key": {
+                    "type": "string",
+                    "example": "atr_72"
+                },
+                "crew_base": {
+                    "type": "string",
+                    "example": "CGK"
+                },
+                "crew_id": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "S001"
+                },
+                "crew_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Sinta"
+                },
+                "departure_airport": {
+                    "type": "string",
+                    "example": "CGK"
+                },
+                "flight_date": {
+                    "type": "string",
+                    "example": "2025-12-01"
+                },
+                "flight_number": {
+                    "type": "string",
+                    "example": "ID001"
+                }
+            }
+        },
+        "dto.VoucherGenerateResponse": {
+            "type": "object",
+            "properties": {
+                "seat_positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeatPosition"
+                    }
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "3B",
+                        "7C",
+                        "14D"
+                    ]
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.VoucherResponse": {
+            "type": "object",
             "properties": {
+                "aircraft_id": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "aircraft_type": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "ATR 72"
                 },
                 "aircraft_type_key": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "atr_72"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "crew_base": {
+                    "type": "string",
+                    "example": "CGK"
+                },
                 "crew_id": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "S001"
                 },
                 "crew_name": {
+                    "type": "string",
+                    "example": "Sinta"
+                },
+                "deleted_at": {
                     "type": "string"
                 },
+                "departure_airport": {
+                    "type": "string",
+                    "example": "CGK"
+                },
                 "flight_date": {
-                    "type": "string"
+                    "type": "string",
+                    "format": "date",
+                    "example": "2025-12-01"
                 },
                 "flight_number": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "ID001"
                 },
                 "id": {
-                    "type": "integer"
+                    "type": "integer",
+                    "example": 1
                 },
                 "seat1": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "1F"
                 },
                 "seat2": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "6A"
                 },
                 "seat3": {
+                    "type": "string",
+                    "example": "2D"
+                },
+                "seat_positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SeatPosition"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "issued",
+                        "cancelled"
+                    ],
+                    "example": "issued"
+                }
+            }
+        },
+        "dto.WebhookAttemptResponse": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "error": {
+                    "type": "string",
+                    "example": "unexpected status 503"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 503
+                }
+            }
+        },
+        "dto.WebhookDeliveryDetailResponse": {
+            "type": "object",
+            "properties": {
+                "attempt_log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.WebhookAttemptResponse"
+                    }
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "voucher.issued"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "evt_9b2f4c0d6a1e4f3b8c7d2e1f0a9b8c7d"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "unexpected status 503"
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is only set while the delivery is pending.",
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "succeeded",
+                        "dead_letter"
+                    ],
+                    "example": "pending"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "voucher.issued"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "evt_9b2f4c0d6a1e4f3b8c7d2e1f0a9b8c7d"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "unexpected status 503"
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "next_attempt_at": {
+                    "description": "NextAttemptAt is only set while the delivery is pending.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "succeeded",
+                        "dead_letter"
+                    ],
+                    "example": "pending"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.WebhookSubscriptionCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Crew app"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "voucher.issued",
+                        "voucher.cancelled"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_5f0c1e..."
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://crew.example.com/hooks/vsa"
+                }
+            }
+        },
+        "dto.WebhookSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true on create and is left as is on update.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Crew app"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "voucher.issued",
+                        "voucher.cancelled"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://crew.example.com/hooks/vsa"
+                }
+            }
+        },
+        "dto.WebhookSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Crew app"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "voucher.issued",
+                        "voucher.cancelled"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://crew.example.com/hooks/vsa"
+                }
+            }
+        },
+        "validators.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
                     "type": "string"
                 }
             }
@@ -344,7 +2691,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/api",
 	Schemes:          []string{},
 	Title:            "Voucher Seat Assignment API",
-	Description:      "This is a service for managing aircraft, flight, and voucher assignments",
+	Description:      "This is a service for managing aircraft, and voucher assignments",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"strings"
+
+	"VSA_GOGIN_BE/models"
+)
+
+// AircraftRequest is the body accepted when creating or updating an aircraft.
+type AircraftRequest struct {
+	AircraftType string   `json:"aircraft_type" binding:"required,max=64" example:"Airbus A320"`
+	NumRows      int      `json:"num_rows" binding:"gt=0,lte=99" example:"32"`
+	SeatsPerRow  string   `json:"seats_per_row" binding:"required,seat_letters" example:"ABC-DEF"`
+	BlockedSeats []string `json:"blocked_seats" binding:"omitempty,max=100,dive,min=1" example:"1A,1F"`
+}
+
+// ToModel builds a new aircraft from the request.
+func (r AircraftRequest) ToModel() models.Aircraft {
+	var aircraft models.Aircraft
+	r.ApplyTo(&aircraft)
+	return aircraft
+}
+
+// ApplyTo copies the editable fields onto an existing aircraft, leaving its
+// ID and derived fields untouched. Blocked seats listed twice are stored once.
+func (r AircraftRequest) ApplyTo(aircraft *models.Aircraft) {
+	aircraft.AircraftType = r.AircraftType
+	aircraft.NumRows = r.NumRows
+	aircraft.SeatsPerRow = r.SeatsPerRow
+	aircraft.BlockedSeats = strings.Join(uniqueSeats(r.BlockedSeats), ",")
+}
+
+func uniqueSeats(seats []string) []string {
+	seen := map[string]bool{}
+	unique := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		seat = strings.TrimSpace(seat)
+		if seat == "" || seen[seat] {
+			continue
+		}
+		seen[seat] = true
+		unique = append(unique, seat)
+	}
+	return unique
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"VSA_GOGIN_BE/models"
+)
+
+// AircraftResponse is the aircraft representation returned by the API.
+type AircraftResponse struct {
+	ID              uint       `json:"id" example:"1"`
+	AircraftType    string     `json:"aircraft_type" example:"Airbus A320"`
+	AircraftTypeKey string     `json:"aircraft_type_key" example:"airbus_a320"`
+	NumRows         int        `json:"num_rows" example:"32"`
+	SeatsPerRow     string     `json:"seats_per_row" example:"ABC-DEF"`
+	BlockedSeats    []string   `json:"blocked_seats" example:"1A,1F"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+}
+
+func NewAircraftResponse(aircraft models.Aircraft) AircraftResponse {
+	return AircraftResponse{
+		ID:              aircraft.ID,
+		AircraftType:    aircraft.AircraftType,
+		AircraftTypeKey: aircraft.AircraftTypeKey,
+		NumRows:         aircraft.NumRows,
+		SeatsPerRow:     aircraft.SeatsPerRow,
+		BlockedSeats:    aircraft.BlockedSeatList(),
+		DeletedAt:       deletedAt(aircraft.DeletedAt),
+	}
+}
+
+func NewAircraftResponses(aircraft []models.Aircraft) []AircraftResponse {
+	responses := make([]AircraftResponse, 0, len(aircraft))
+	for _, a := range aircraft {
+		responses = append(responses, NewAircraftResponse(a))
+	}
+	return responses
+}
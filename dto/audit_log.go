@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+)
+
+// AuditLogQuery holds the filters accepted by the audit log endpoints.
+type AuditLogQuery struct {
+	Entity    string    `form:"entity" binding:"omitempty,oneof=aircraft voucher webhook"`
+	EntityID  uint      `form:"entity_id"`
+	Actor     string    `form:"actor"`
+	Action    string    `form:"action" binding:"omitempty,oneof=create update delete restore generate regenerate"`
+	RequestID string    `form:"request_id"`
+	From      time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit     int       `form:"limit" binding:"gte=0,lte=1000"`
+	Offset    int       `form:"offset" binding:"gte=0"`
+}
+
+// ToFilter maps the query onto the repository filter.
+func (q AuditLogQuery) ToFilter() repositories.AuditLogFilter {
+	return repositories.AuditLogFilter{
+		Entity:    q.Entity,
+		EntityID:  q.EntityID,
+		Actor:     q.Actor,
+		Action:    q.Action,
+		RequestID: q.RequestID,
+		From:      q.From,
+		To:        q.To,
+		Limit:     q.Limit,
+		Offset:    q.Offset,
+	}
+}
+
+// AuditLogExportQuery adds the export format to the audit log filters.
+type AuditLogExportQuery struct {
+	AuditLogQuery
+	Format string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+// AuditLogResponse is the audit log entry representation returned by the API.
+type AuditLogResponse struct {
+	ID              uint            `json:"id" example:"1"`
+	Actor           string          `json:"actor" example:"ops@example.com"`
+	Action          string          `json:"action" example:"update"`
+	Entity          string          `json:"entity" example:"aircraft"`
+	EntityID        uint            `json:"entity_id" example:"2"`
+	RequestID       string          `json:"request_id,omitempty"`
+	RemoteAddr      string          `json:"remote_addr,omitempty" example:"10.0.0.7"`
+	Before          json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After           json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+	Diff            json.RawMessage `json:"diff,omitempty" swaggertype:"object"`
+	SeatsConsidered json.RawMessage `json:"seats_considered,omitempty" swaggertype:"array,string"`
+	SeatsChosen     json.RawMessage `json:"seats_chosen,omitempty" swaggertype:"array,string"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+func NewAuditLogResponse(entry models.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:              entry.ID,
+		Actor:           entry.Actor,
+		Action:          entry.Action,
+		Entity:          entry.Entity,
+		EntityID:        entry.EntityID,
+		RequestID:       entry.RequestID,
+		RemoteAddr:      entry.RemoteAddr,
+		Before:          rawJSON(entry.Before),
+		After:           rawJSON(entry.After),
+		Diff:            rawJSON(entry.Diff),
+		SeatsConsidered: rawJSON(entry.SeatsConsidered),
+		SeatsChosen:     rawJSON(entry.SeatsChosen),
+		CreatedAt:       entry.CreatedAt,
+	}
+}
+
+func NewAuditLogResponses(entries []models.AuditLog) []AuditLogResponse {
+	responses := make([]AuditLogResponse, 0, len(entries))
+	for _, e := range entries {
+		responses = append(responses, NewAuditLogResponse(e))
+	}
+	return responses
+}
+
+func rawJSON(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// csvFormulaPrefixes are the leading characters spreadsheets evaluate as a
+// formula when they open a CSV file.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// EscapeCSVCell prefixes value with a single quote when a spreadsheet would
+// otherwise evaluate it as a formula, e.g. "=HYPERLINK(...)".
+func EscapeCSVCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// WriteCSV writes the header and records to w, escaping every cell with
+// EscapeCSVCell, and returns the first write error.
+func WriteCSV(w io.Writer, header []string, records [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, record := range records {
+		escaped := make([]string, len(record))
+		for i, value := range record {
+			escaped[i] = EscapeCSVCell(value)
+		}
+		if err := writer.Write(escaped); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscapeCSVCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"ID001", "ID001"},
+		{"0.1333", "0.1333"},
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+1", "'+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"a=b", "a=b"},
+	}
+	for _, tt := range tests {
+		if got := EscapeCSVCell(tt.value); got != tt.want {
+			t.Errorf("EscapeCSVCell(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSVEscapesRecordsButNotHeader(t *testing.T) {
+	var b strings.Builder
+	if err := WriteCSV(&b, []string{"crew_name", "seats"}, [][]string{{"=cmd", "1A"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "crew_name,seats\n'=cmd,1A\n"; b.String() != want {
+		t.Errorf("WriteCSV wrote %q, want %q", b.String(), want)
+	}
+}
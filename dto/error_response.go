@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"context"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/validators"
+)
+
+// ErrorResponse is the body returned for failed requests.
+type ErrorResponse struct {
+	Error     string `json:"error" example:"Aircraft not found"`
+	RequestID string `json:"request_id,omitempty" example:"5f2b9c1e8d4a4f6b9e3c2a1d0b7e6f5a"`
+}
+
+// NewErrorResponse builds an error response carrying the ID of the request
+// in ctx, so clients can quote it when reporting problems.
+func NewErrorResponse(ctx context.Context, message string) ErrorResponse {
+	return ErrorResponse{Error: message, RequestID: logging.RequestID(ctx)}
+}
+
+// ValidationErrorResponse is the body returned when request validation fails.
+type ValidationErrorResponse struct {
+	Error     string                  `json:"error" example:"validation failed"`
+	Fields    []validators.FieldError `json:"fields"`
+	RequestID string                  `json:"request_id,omitempty" example:"5f2b9c1e8d4a4f6b9e3c2a1d0b7e6f5a"`
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"VSA_GOGIN_BE/services"
+)
+
+// EventStreamQuery pages through the event stream.
+type EventStreamQuery struct {
+	// After is the stream ID of the last entry already read; empty starts
+	// at the beginning of the stream.
+	After string `form:"after"`
+	Count int64  `form:"count" binding:"gte=0,lte=1000"`
+}
+
+// EventReplayRequest is the body accepted when replaying the stream to a
+// consumer group.
+type EventReplayRequest struct {
+	// From is the stream ID of the last entry the group should count as
+	// read; "0" replays the whole stream.
+	From string `json:"from" binding:"required" example:"1767225600000-0"`
+}
+
+// StreamEventResponse is one event stream entry.
+type StreamEventResponse struct {
+	StreamID string          `json:"stream_id" example:"1767225600000-0"`
+	EventID  string          `json:"event_id" example:"evt_9b2f4c0d6a1e4f3b8c7d2e1f0a9b8c7d"`
+	Type     string          `json:"type" example:"voucher.issued"`
+	Payload  json.RawMessage `json:"payload" swaggertype:"object"`
+}
+
+// EventStreamResponse holds a page of the event stream. Pass Next as after
+// to read the following page.
+type EventStreamResponse struct {
+	Events []StreamEventResponse `json:"events"`
+	Next   string                `json:"next,omitempty" example:"1767225600000-0"`
+}
+
+func NewEventStreamResponse(events []services.StreamEvent) EventStreamResponse {
+	response := EventStreamResponse{Events: make([]StreamEventResponse, 0, len(events))}
+	for _, e := range events {
+		response.Events = append(response.Events, StreamEventResponse{
+			StreamID: e.StreamID,
+			EventID:  e.EventID,
+			Type:     e.Type,
+			Payload:  rawJSON(e.Payload),
+		})
+		response.Next = e.StreamID
+	}
+	return response
+}
+
+// EventStreamInfoResponse describes the event stream, its consumer groups
+// and the outbox backlog not relayed yet.
+type EventStreamInfoResponse struct {
+	Stream  string                  `json:"stream" example:"vsa:events"`
+	Length  int64                   `json:"length" example:"1520"`
+	Backlog int64                   `json:"backlog" example:"0"`
+	Groups  []ConsumerGroupResponse `json:"groups"`
+}
+
+// ConsumerGroupResponse describes how far a consumer group has read.
+type ConsumerGroupResponse struct {
+	Name            string `json:"name" example:"data-platform"`
+	Consumers       int64  `json:"consumers" example:"2"`
+	Pending         int64  `json:"pending" example:"3"`
+	LastDeliveredID string `json:"last_delivered_id" example:"1767225600000-0"`
+	Lag             int64  `json:"lag" example:"12"`
+}
+
+func NewEventStreamInfoResponse(info services.EventStreamInfo) EventStreamInfoResponse {
+	groups := make([]ConsumerGroupResponse, 0, len(info.Groups))
+	for _, g := range info.Groups {
+		groups = append(groups, ConsumerGroupResponse{
+			Name:            g.Name,
+			Consumers:       g.Consumers,
+			Pending:         g.Pending,
+			LastDeliveredID: g.LastDeliveredID,
+			Lag:             g.Lag,
+		})
+	}
+	return EventStreamInfoResponse{
+		Stream:  info.Stream,
+		Length:  info.Length,
+		Backlog: info.Backlog,
+		Groups:  groups,
+	}
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "VSA_GOGIN_BE/services"
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// HealthResponse is the body of the health endpoints.
+type HealthResponse struct {
+	Status string                         `json:"status" example:"ok"`
+	Checks map[string]HealthCheckResponse `json:"checks"`
+}
+
+// HealthCheckResponse reports the state of one dependency.
+type HealthCheckResponse struct {
+	Status    string  `json:"status" example:"ok"`
+	LatencyMs float64 `json:"latency_ms" example:"1.25"`
+	Error     string  `json:"error,omitempty" example:"dial tcp redis:6379: connect: connection refused"`
+}
+
+// NewHealthResponse maps check results onto the response body.
+func NewHealthResponse(results []services.HealthCheckResult, healthy bool) HealthResponse {
+	response := HealthResponse{
+		Status: HealthStatusOK,
+		Checks: make(map[string]HealthCheckResponse, len(results)),
+	}
+	if !healthy {
+		response.Status = HealthStatusUnavailable
+	}
+	for _, result := range results {
+		check := HealthCheckResponse{
+			Status:    HealthStatusOK,
+			LatencyMs: float64(result.Latency.Microseconds()) / 1000,
+		}
+		if result.Err != nil {
+			check.Status = HealthStatusUnavailable
+			check.Error = result.Err.Error()
+		}
+		response.Checks[result.Name] = check
+	}
+	return response
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ListQuery holds the query parameters shared by list endpoints.
+type ListQuery struct {
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// deletedAt returns the soft-delete time, or nil for active records.
+func deletedAt(value gorm.DeletedAt) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
@@ -0,0 +1,225 @@
+package dto
+
+import (
+	"errors"
+	"strconv"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/services"
+)
+
+// ReportQuery holds the filters accepted by the report endpoints.
+type ReportQuery struct {
+	From            string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To              string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+	AircraftTypeKey string `form:"aircraft_type_key"`
+	CrewBase        string `form:"crew_base" binding:"omitempty,airport"`
+	Format          string `form:"format" binding:"omitempty,oneof=json csv"`
+	Limit           int    `form:"limit" binding:"gte=0,lte=1000"`
+	Offset          int    `form:"offset" binding:"gte=0"`
+}
+
+// ToFilter maps the query onto the repository filter.
+func (q ReportQuery) ToFilter() (repositories.ReportFilter, error) {
+	filter := repositories.ReportFilter{
+		AircraftTypeKey: q.AircraftTypeKey,
+		CrewBase:        q.CrewBase,
+		Limit:           q.Limit,
+		Offset:          q.Offset,
+	}
+
+	var err error
+	if q.From != "" {
+		if filter.From, err = models.ParseDate(q.From); err != nil {
+			return filter, err
+		}
+	}
+	if q.To != "" {
+		if filter.To, err = models.ParseDate(q.To); err != nil {
+			return filter, err
+		}
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return filter, errors.New("to must not be before from")
+	}
+	return filter, nil
+}
+
+// FlightReportResponse is the seat utilization of one flight.
+type FlightReportResponse struct {
+	FlightNumber    string      `json:"flight_number" example:"ID001"`
+	FlightDate      models.Date `json:"flight_date" swaggertype:"string" format:"date" example:"2025-12-01"`
+	AircraftType    string      `json:"aircraft_type" example:"ATR 72"`
+	AircraftTypeKey string      `json:"aircraft_type_key" example:"atr_72"`
+	Vouchers        int         `json:"vouchers" example:"4"`
+	SeatsAssigned   int         `json:"seats_assigned" example:"12"`
+	Capacity        int         `json:"capacity" example:"90"`
+	SeatsRemaining  int         `json:"seats_remaining" example:"78"`
+	FillRate        float64     `json:"fill_rate" example:"0.1333"`
+}
+
+// FlightReportCSVHeader names the columns of FlightReportResponse.CSVRecord.
+var FlightReportCSVHeader = []string{"flight_number", "flight_date", "aircraft_type", "aircraft_type_key", "vouchers", "seats_assigned", "capacity", "seats_remaining", "fill_rate"}
+
+func (r FlightReportResponse) CSVRecord() []string {
+	return []string{
+		r.FlightNumber,
+		r.FlightDate.String(),
+		r.AircraftType,
+		r.AircraftTypeKey,
+		strconv.Itoa(r.Vouchers),
+		strconv.Itoa(r.SeatsAssigned),
+		strconv.Itoa(r.Capacity),
+		strconv.Itoa(r.SeatsRemaining),
+		formatFillRate(r.FillRate),
+	}
+}
+
+func NewFlightReportResponses(flights []services.FlightUtilization) []FlightReportResponse {
+	responses := make([]FlightReportResponse, 0, len(flights))
+	for _, f := range flights {
+		responses = append(responses, FlightReportResponse{
+			FlightNumber:    f.FlightNumber,
+			FlightDate:      f.FlightDate,
+			AircraftType:    f.Aircraft.AircraftType,
+			AircraftTypeKey: f.Aircraft.AircraftTypeKey,
+			Vouchers:        f.Vouchers,
+			SeatsAssigned:   f.SeatsAssigned,
+			Capacity:        f.Capacity,
+			SeatsRemaining:  f.SeatsRemaining,
+			FillRate:        f.FillRate,
+		})
+	}
+	return responses
+}
+
+// AircraftTypeReportResponse is the seat utilization of one aircraft type
+// over its flights with vouchers.
+type AircraftTypeReportResponse struct {
+	AircraftType    string  `json:"aircraft_type" example:"ATR 72"`
+	AircraftTypeKey string  `json:"aircraft_type_key" example:"atr_72"`
+	Flights         int     `json:"flights" example:"3"`
+	Vouchers        int     `json:"vouchers" example:"10"`
+	SeatsAssigned   int     `json:"seats_assigned" example:"30"`
+	Capacity        int     `json:"capacity" example:"270"`
+	FillRate        float64 `json:"fill_rate" example:"0.1111"`
+}
+
+// AircraftTypeReportCSVHeader names the columns of
+// AircraftTypeReportResponse.CSVRecord.
+var AircraftTypeReportCSVHeader = []string{"aircraft_type", "aircraft_type_key", "flights", "vouchers", "seats_assigned", "capacity", "fill_rate"}
+
+func (r AircraftTypeReportResponse) CSVRecord() []string {
+	return []string{
+		r.AircraftType,
+		r.AircraftTypeKey,
+		strconv.Itoa(r.Flights),
+		strconv.Itoa(r.Vouchers),
+		strconv.Itoa(r.SeatsAssigned),
+		strconv.Itoa(r.Capacity),
+		formatFillRate(r.FillRate),
+	}
+}
+
+func NewAircraftTypeReportResponses(types []services.AircraftTypeUtilization) []AircraftTypeReportResponse {
+	responses := make([]AircraftTypeReportResponse, 0, len(types))
+	for _, t := range types {
+		responses = append(responses, AircraftTypeReportResponse{
+			AircraftType:    t.AircraftType,
+			AircraftTypeKey: t.AircraftTypeKey,
+			Flights:         t.Flights,
+			Vouchers:        t.Vouchers,
+			SeatsAssigned:   t.SeatsAssigned,
+			Capacity:        t.Capacity,
+			FillRate:        t.FillRate,
+		})
+	}
+	return responses
+}
+
+// CrewBaseReportResponse is the voucher usage of crew from one home base.
+// CrewBase is empty for vouchers issued without one.
+type CrewBaseReportResponse struct {
+	CrewBase      string `json:"crew_base" example:"CGK"`
+	Vouchers      int    `json:"vouchers" example:"25"`
+	CrewMembers   int    `json:"crew_members" example:"9"`
+	Flights       int    `json:"flights" example:"14"`
+	SeatsAssigned int    `json:"seats_assigned" example:"75"`
+}
+
+// CrewBaseReportCSVHeader names the columns of CrewBaseReportResponse.CSVRecord.
+var CrewBaseReportCSVHeader = []string{"crew_base", "vouchers", "crew_members", "flights", "seats_assigned"}
+
+func (r CrewBaseReportResponse) CSVRecord() []string {
+	return []string{
+		r.CrewBase,
+		strconv.Itoa(r.Vouchers),
+		strconv.Itoa(r.CrewMembers),
+		strconv.Itoa(r.Flights),
+		strconv.Itoa(r.SeatsAssigned),
+	}
+}
+
+func NewCrewBaseReportResponses(rows []repositories.CrewBaseReportRow) []CrewBaseReportResponse {
+	responses := make([]CrewBaseReportResponse, 0, len(rows))
+	for _, r := range rows {
+		responses = append(responses, CrewBaseReportResponse{
+			CrewBase:      r.CrewBase,
+			Vouchers:      r.Vouchers,
+			CrewMembers:   r.CrewMembers,
+			Flights:       r.Flights,
+			SeatsAssigned: r.SeatsAssigned,
+		})
+	}
+	return responses
+}
+
+// CrewReportResponse is the voucher usage of one crew member.
+type CrewReportResponse struct {
+	CrewID        string      `json:"crew_id" example:"S001"`
+	CrewName      string      `json:"crew_name" example:"Sinta"`
+	CrewBase      string      `json:"crew_base" example:"CGK"`
+	Vouchers      int         `json:"vouchers" example:"3"`
+	Flights       int         `json:"flights" example:"3"`
+	SeatsAssigned int         `json:"seats_assigned" example:"9"`
+	FirstFlight   models.Date `json:"first_flight" swaggertype:"string" format:"date" example:"2025-12-01"`
+	LastFlight    models.Date `json:"last_flight" swaggertype:"string" format:"date" example:"2025-12-09"`
+}
+
+// CrewReportCSVHeader names the columns of CrewReportResponse.CSVRecord.
+var CrewReportCSVHeader = []string{"crew_id", "crew_name", "crew_base", "vouchers", "flights", "seats_assigned", "first_flight", "last_flight"}
+
+func (r CrewReportResponse) CSVRecord() []string {
+	return []string{
+		r.CrewID,
+		r.CrewName,
+		r.CrewBase,
+		strconv.Itoa(r.Vouchers),
+		strconv.Itoa(r.Flights),
+		strconv.Itoa(r.SeatsAssigned),
+		r.FirstFlight.String(),
+		r.LastFlight.String(),
+	}
+}
+
+func NewCrewReportResponses(rows []repositories.CrewReportRow) []CrewReportResponse {
+	responses := make([]CrewReportResponse, 0, len(rows))
+	for _, r := range rows {
+		responses = append(responses, CrewReportResponse{
+			CrewID:        r.CrewID,
+			CrewName:      r.CrewName,
+			CrewBase:      r.CrewBase,
+			Vouchers:      r.Vouchers,
+			Flights:       r.Flights,
+			SeatsAssigned: r.SeatsAssigned,
+			FirstFlight:   r.FirstFlight,
+			LastFlight:    r.LastFlight,
+		})
+	}
+	return responses
+}
+
+func formatFillRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', 4, 64)
+}
@@ -0,0 +1,25 @@
+package dto
+
+import "VSA_GOGIN_BE/models"
+
+// Seat map formats
+const (
+	SeatMapFormatJSON  = "json"
+	SeatMapFormatASCII = "ascii"
+	SeatMapFormatSVG   = "svg"
+)
+
+// SeatMapRequest identifies the flight whose seat map is requested. The
+// flight number and date come from the path, the rest from the query string.
+type SeatMapRequest struct {
+	FlightNumber     string `uri:"number" binding:"required,flight_number"`
+	FlightDate       string `uri:"date" binding:"required,flight_date"`
+	DepartureAirport string `form:"departure_airport" binding:"omitempty,airport"`
+	AircraftTypeKey  string `form:"aircraft_type_key"`
+	Format           string `form:"format" binding:"omitempty,oneof=json ascii svg"`
+}
+
+// Date returns the local departure date of the flight.
+func (r SeatMapRequest) Date() (models.Date, error) {
+	return models.FlightDateFor(r.FlightDate, r.DepartureAirport)
+}
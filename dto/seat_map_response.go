@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/services"
+)
+
+// SeatMapResponse is the cabin of a flight with the status of every seat.
+type SeatMapResponse struct {
+	FlightNumber string               `json:"flight_number" example:"ID001"`
+	FlightDate   models.Date          `json:"flight_date" swaggertype:"string" format:"date" example:"2025-12-01"`
+	Aircraft     AircraftResponse     `json:"aircraft"`
+	Columns      []SeatMapColumn      `json:"columns"`
+	Aisles       []SeatMapAisle       `json:"aisles"`
+	Rows         []SeatMapRowResponse `json:"rows"`
+	Summary      SeatMapSummary       `json:"summary"`
+}
+
+// SeatMapColumn is a seat letter and where in the row it sits.
+type SeatMapColumn struct {
+	Letter   string `json:"letter" example:"A"`
+	Position string `json:"position" example:"window" enums:"window,middle,aisle"`
+}
+
+// SeatMapAisle is an aisle between two seat letters.
+type SeatMapAisle struct {
+	After  string `json:"after" example:"C"`
+	Before string `json:"before" example:"D"`
+}
+
+// SeatMapRowResponse holds the seats of one row.
+type SeatMapRowResponse struct {
+	Row   int                   `json:"row" example:"1"`
+	Seats []SeatMapSeatResponse `json:"seats"`
+}
+
+// SeatMapSeatResponse is one seat and its status.
+type SeatMapSeatResponse struct {
+	Seat      string `json:"seat" example:"1A"`
+	Letter    string `json:"letter" example:"A"`
+	Position  string `json:"position" example:"window" enums:"window,middle,aisle"`
+	Status    string `json:"status" example:"free" enums:"free,assigned,blocked"`
+	VoucherID uint   `json:"voucher_id,omitempty" example:"1"`
+}
+
+// SeatMapSummary counts the seats by status.
+type SeatMapSummary struct {
+	Free     int `json:"free" example:"180"`
+	Assigned int `json:"assigned" example:"9"`
+	Blocked  int `json:"blocked" example:"3"`
+}
+
+func NewSeatMapResponse(seatMap *services.SeatMap) SeatMapResponse {
+	response := SeatMapResponse{
+		FlightNumber: seatMap.FlightNumber,
+		FlightDate:   seatMap.FlightDate,
+		Aircraft:     NewAircraftResponse(seatMap.Aircraft),
+		Columns:      []SeatMapColumn{},
+		Aisles:       []SeatMapAisle{},
+		Rows:         make([]SeatMapRowResponse, 0, len(seatMap.Rows)),
+		Summary: SeatMapSummary{
+			Free:     seatMap.Count(services.SeatFree),
+			Assigned: seatMap.Count(services.SeatAssigned),
+			Blocked:  seatMap.Count(services.SeatBlocked),
+		},
+	}
+
+	positions := seatMap.Aircraft.SeatPositions()
+	for _, letter := range seatMap.Aircraft.SeatLetters() {
+		response.Columns = append(response.Columns, SeatMapColumn{Letter: string(letter), Position: positions[letter]})
+	}
+	for i := 1; i < len(seatMap.Groups); i++ {
+		before, after := seatMap.Groups[i-1], seatMap.Groups[i]
+		response.Aisles = append(response.Aisles, SeatMapAisle{
+			After:  before[len(before)-1:],
+			Before: after[:1],
+		})
+	}
+
+	for i, row := range seatMap.Rows {
+		seats := make([]SeatMapSeatResponse, 0, len(row))
+		for _, seat := range row {
+			seats = append(seats, SeatMapSeatResponse{
+				Seat:      seat.Seat,
+				Letter:    seat.Letter,
+				Position:  seat.Position,
+				Status:    seat.Status,
+				VoucherID: seat.VoucherID,
+			})
+		}
+		response.Rows = append(response.Rows, SeatMapRowResponse{Row: i + 1, Seats: seats})
+	}
+	return response
+}
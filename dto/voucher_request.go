@@ -0,0 +1,65 @@
+package dto
+
+import "VSA_GOGIN_BE/models"
+
+// VoucherGenerateRequest is the body accepted when generating voucher seats.
+// FlightDate is the local departure date; timestamps are converted to the
+// departure airport's time zone.
+type VoucherGenerateRequest struct {
+	CrewName         string `json:"crew_name" binding:"required,max=100" example:"Sinta"`
+	CrewID           string `json:"crew_id" binding:"required,max=32" example:"S001"`
+	CrewBase         string `json:"crew_base" binding:"omitempty,airport" example:"CGK"`
+	FlightNumber     string `json:"flight_number" binding:"required,flight_number" example:"ID001"`
+	FlightDate       string `json:"flight_date" binding:"required,flight_date" example:"2025-12-01"`
+	DepartureAirport string `json:"departure_airport" binding:"omitempty,airport" example:"CGK"`
+	AircraftTypeKey  string `json:"aircraft_type_key" binding:"required" example:"atr_72"`
+}
+
+// ToModel builds the voucher to be generated from the request.
+func (r VoucherGenerateRequest) ToModel() (models.Voucher, error) {
+	flightDate, err := models.FlightDateFor(r.FlightDate, r.DepartureAirport)
+	if err != nil {
+		return models.Voucher{}, err
+	}
+
+	return models.Voucher{
+		CrewName:         r.CrewName,
+		CrewID:           r.CrewID,
+		CrewBase:         r.CrewBase,
+		FlightNumber:     r.FlightNumber,
+		FlightDate:       flightDate,
+		DepartureAirport: r.DepartureAirport,
+		AircraftTypeKey:  r.AircraftTypeKey,
+	}, nil
+}
+
+// VoucherCheckRequest is the body accepted when checking for an existing voucher.
+type VoucherCheckRequest struct {
+	CrewID           string `json:"crew_id" binding:"required,max=32" example:"S001"`
+	FlightNumber     string `json:"flight_number" binding:"required,flight_number" example:"ID001"`
+	FlightDate       string `json:"flight_date" binding:"required,flight_date" example:"2025-12-01"`
+	DepartureAirport string `json:"departure_airport" binding:"omitempty,airport" example:"CGK"`
+	// AircraftTypeKey is accepted for compatibility but not matched on: a
+	// crew member holds at most one voucher per flight whatever the aircraft.
+	AircraftTypeKey string `json:"aircraft_type_key" example:"atr_72"`
+}
+
+// VoucherCheckBatchRequest checks several crew members and flights at once.
+type VoucherCheckBatchRequest struct {
+	Items []VoucherCheckRequest `json:"items" binding:"required,min=1,max=100,dive"`
+}
+
+// ToModel builds the voucher lookup criteria from the request.
+func (r VoucherCheckRequest) ToModel() (models.Voucher, error) {
+	flightDate, err := models.FlightDateFor(r.FlightDate, r.DepartureAirport)
+	if err != nil {
+		return models.Voucher{}, err
+	}
+
+	return models.Voucher{
+		CrewID:          r.CrewID,
+		FlightNumber:    r.FlightNumber,
+		FlightDate:      flightDate,
+		AircraftTypeKey: r.AircraftTypeKey,
+	}, nil
+}
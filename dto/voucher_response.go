@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"time"
+
+	"VSA_GOGIN_BE/models"
+)
+
+// VoucherResponse is the voucher representation returned by the API.
+type VoucherResponse struct {
+	ID               uint           `json:"id" example:"1"`
+	CrewName         string         `json:"crew_name" example:"Sinta"`
+	CrewID           string         `json:"crew_id" example:"S001"`
+	CrewBase         string         `json:"crew_base,omitempty" example:"CGK"`
+	FlightNumber     string         `json:"flight_number" example:"ID001"`
+	FlightDate       models.Date    `json:"flight_date" swaggertype:"string" format:"date" example:"2025-12-01"`
+	DepartureAirport string         `json:"departure_airport,omitempty" example:"CGK"`
+	AircraftID       *uint          `json:"aircraft_id" example:"1"`
+	AircraftType     string         `json:"aircraft_type" example:"ATR 72"`
+	AircraftTypeKey  string         `json:"aircraft_type_key" example:"atr_72"`
+	Seat1            string         `json:"seat1" example:"1F"`
+	Seat2            string         `json:"seat2" example:"6A"`
+	Seat3            string         `json:"seat3" example:"2D"`
+	SeatPositions    []SeatPosition `json:"seat_positions"`
+	CreatedAt        time.Time      `json:"created_at"`
+	Status           string         `json:"status" example:"issued" enums:"issued,cancelled"`
+	DeletedAt        *time.Time     `json:"deleted_at,omitempty"`
+}
+
+// Voucher statuses
+const (
+	VoucherStatusIssued    = "issued"
+	VoucherStatusCancelled = "cancelled"
+)
+
+func NewVoucherResponse(voucher models.Voucher) VoucherResponse {
+	return VoucherResponse{
+		ID:               voucher.ID,
+		CrewName:         voucher.CrewName,
+		CrewID:           voucher.CrewID,
+		CrewBase:         voucher.CrewBase,
+		FlightNumber:     voucher.FlightNumber,
+		FlightDate:       voucher.FlightDate,
+		DepartureAirport: voucher.DepartureAirport,
+		AircraftID:       voucher.AircraftID,
+		AircraftType:     voucher.AircraftType,
+		AircraftTypeKey:  voucher.AircraftTypeKey,
+		Seat1:            voucher.Seat1,
+		Seat2:            voucher.Seat2,
+		Seat3:            voucher.Seat3,
+		SeatPositions:    NewSeatPositions(voucher.Seats(), voucher.Aircraft),
+		CreatedAt:        voucher.CreatedAt,
+		Status:           voucherStatus(voucher),
+		DeletedAt:        deletedAt(voucher.DeletedAt),
+	}
+}
+
+func voucherStatus(voucher models.Voucher) string {
+	if voucher.DeletedAt.Valid {
+		return VoucherStatusCancelled
+	}
+	return VoucherStatusIssued
+}
+
+func NewVoucherResponses(vouchers []models.Voucher) []VoucherResponse {
+	responses := make([]VoucherResponse, 0, len(vouchers))
+	for _, v := range vouchers {
+		responses = append(responses, NewVoucherResponse(v))
+	}
+	return responses
+}
+
+// SeatPosition is an assigned seat and where in the row it sits. Position is
+// empty when the voucher's aircraft is unknown.
+type SeatPosition struct {
+	Seat     string `json:"seat" example:"1F"`
+	Position string `json:"position,omitempty" example:"window" enums:"window,middle,aisle"`
+}
+
+func NewSeatPositions(seats []string, aircraft *models.Aircraft) []SeatPosition {
+	positions := make([]SeatPosition, 0, len(seats))
+	for _, seat := range seats {
+		position := SeatPosition{Seat: seat}
+		if aircraft != nil {
+			position.Position = aircraft.SeatPosition(seat)
+		}
+		positions = append(positions, position)
+	}
+	return positions
+}
+
+// VoucherGenerateResponse is returned after seats have been assigned.
+type VoucherGenerateResponse struct {
+	Success       bool           `json:"success" example:"true"`
+	Seats         []string       `json:"seats" example:"3B,7C,14D"`
+	SeatPositions []SeatPosition `json:"seat_positions"`
+}
+
+// VoucherCheckResponse reports whether a voucher was already generated and,
+// if so, returns it.
+type VoucherCheckResponse struct {
+	Exists  bool             `json:"exists" example:"true"`
+	Voucher *VoucherResponse `json:"voucher,omitempty"`
+}
+
+func NewVoucherCheckResponse(voucher *models.Voucher) VoucherCheckResponse {
+	if voucher == nil {
+		return VoucherCheckResponse{Exists: false}
+	}
+	response := NewVoucherResponse(*voucher)
+	return VoucherCheckResponse{Exists: true, Voucher: &response}
+}
+
+// VoucherCheckResult is the outcome of one item of a batch check.
+type VoucherCheckResult struct {
+	VoucherCheckRequest
+	VoucherCheckResponse
+}
+
+// VoucherCheckBatchResponse lists the batch check results in request order.
+type VoucherCheckBatchResponse struct {
+	Results []VoucherCheckResult `json:"results"`
+}
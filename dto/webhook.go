@@ -0,0 +1,175 @@
+package dto
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+)
+
+// WebhookSubscriptionRequest is the body accepted when creating or updating
+// a webhook subscription.
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required,http_url,max=2048" example:"https://crew.example.com/hooks/vsa"`
+	Events      []string `json:"events" binding:"required,min=1,dive,oneof=voucher.issued voucher.regenerated voucher.cancelled voucher.restored" example:"voucher.issued,voucher.cancelled"`
+	Description string   `json:"description" binding:"max=255" example:"Crew app"`
+	// Active defaults to true on create and is left as is on update.
+	Active *bool `json:"active" example:"true"`
+}
+
+// ToModel builds a new, active subscription from the request.
+func (r WebhookSubscriptionRequest) ToModel() models.WebhookSubscription {
+	subscription := models.WebhookSubscription{Active: true}
+	r.ApplyTo(&subscription)
+	return subscription
+}
+
+// ApplyTo copies the editable fields onto an existing subscription, leaving
+// its ID and secret untouched.
+func (r WebhookSubscriptionRequest) ApplyTo(subscription *models.WebhookSubscription) {
+	subscription.URL = r.URL
+	subscription.Events = strings.Join(r.Events, ",")
+	subscription.Description = r.Description
+	if r.Active != nil {
+		subscription.Active = *r.Active
+	}
+}
+
+// WebhookSubscriptionResponse is the webhook subscription representation
+// returned by the API. The secret is left out.
+type WebhookSubscriptionResponse struct {
+	ID          uint      `json:"id" example:"1"`
+	URL         string    `json:"url" example:"https://crew.example.com/hooks/vsa"`
+	Events      []string  `json:"events" example:"voucher.issued,voucher.cancelled"`
+	Description string    `json:"description,omitempty" example:"Crew app"`
+	Active      bool      `json:"active" example:"true"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewWebhookSubscriptionResponse(subscription models.WebhookSubscription) WebhookSubscriptionResponse {
+	return WebhookSubscriptionResponse{
+		ID:          subscription.ID,
+		URL:         subscription.URL,
+		Events:      subscription.EventList(),
+		Description: subscription.Description,
+		Active:      subscription.Active,
+		CreatedAt:   subscription.CreatedAt,
+		UpdatedAt:   subscription.UpdatedAt,
+	}
+}
+
+func NewWebhookSubscriptionResponses(subscriptions []models.WebhookSubscription) []WebhookSubscriptionResponse {
+	responses := make([]WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		responses = append(responses, NewWebhookSubscriptionResponse(s))
+	}
+	return responses
+}
+
+// WebhookSubscriptionCreatedResponse adds the signing secret, which is only
+// returned when the subscription is created.
+type WebhookSubscriptionCreatedResponse struct {
+	WebhookSubscriptionResponse
+	Secret string `json:"secret" example:"whsec_5f0c1e..."`
+}
+
+// WebhookDeliveryQuery holds the filters accepted by the delivery log.
+type WebhookDeliveryQuery struct {
+	SubscriptionID uint   `form:"subscription_id"`
+	Event          string `form:"event" binding:"omitempty,oneof=voucher.issued voucher.regenerated voucher.cancelled voucher.restored"`
+	Status         string `form:"status" binding:"omitempty,oneof=pending succeeded dead_letter"`
+	Limit          int    `form:"limit" binding:"gte=0,lte=1000"`
+	Offset         int    `form:"offset" binding:"gte=0"`
+}
+
+// ToFilter maps the query onto the repository filter.
+func (q WebhookDeliveryQuery) ToFilter() repositories.WebhookDeliveryFilter {
+	return repositories.WebhookDeliveryFilter{
+		SubscriptionID: q.SubscriptionID,
+		Event:          q.Event,
+		Status:         q.Status,
+		Limit:          q.Limit,
+		Offset:         q.Offset,
+	}
+}
+
+// WebhookDeliveryResponse is one entry of the delivery log.
+type WebhookDeliveryResponse struct {
+	ID             uint   `json:"id" example:"12"`
+	SubscriptionID uint   `json:"subscription_id" example:"1"`
+	EventID        string `json:"event_id" example:"evt_9b2f4c0d6a1e4f3b8c7d2e1f0a9b8c7d"`
+	Event          string `json:"event" example:"voucher.issued"`
+	Status         string `json:"status" example:"pending" enums:"pending,succeeded,dead_letter"`
+	Attempts       int    `json:"attempts" example:"2"`
+	// NextAttemptAt is only set while the delivery is pending.
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty" example:"503"`
+	LastError      string     `json:"last_error,omitempty" example:"unexpected status 503"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func NewWebhookDeliveryResponse(delivery models.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		Event:          delivery.Event,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	if delivery.Status == models.DeliveryPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return response
+}
+
+func NewWebhookDeliveryResponses(deliveries []models.WebhookDelivery) []WebhookDeliveryResponse {
+	responses := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		responses = append(responses, NewWebhookDeliveryResponse(d))
+	}
+	return responses
+}
+
+// WebhookDeliveryDetailResponse adds the payload and every attempt made to
+// a delivery log entry.
+type WebhookDeliveryDetailResponse struct {
+	WebhookDeliveryResponse
+	Payload    json.RawMessage          `json:"payload" swaggertype:"object"`
+	AttemptLog []WebhookAttemptResponse `json:"attempt_log"`
+}
+
+// WebhookAttemptResponse is one POST of a delivery.
+type WebhookAttemptResponse struct {
+	Attempt    int       `json:"attempt" example:"1"`
+	StatusCode int       `json:"status_code,omitempty" example:"503"`
+	Error      string    `json:"error,omitempty" example:"unexpected status 503"`
+	DurationMs int64     `json:"duration_ms" example:"84"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func NewWebhookDeliveryDetailResponse(delivery models.WebhookDelivery) WebhookDeliveryDetailResponse {
+	attempts := make([]WebhookAttemptResponse, 0, len(delivery.AttemptLog))
+	for _, a := range delivery.AttemptLog {
+		attempts = append(attempts, WebhookAttemptResponse{
+			Attempt:    a.Attempt,
+			StatusCode: a.StatusCode,
+			Error:      a.Error,
+			DurationMs: a.DurationMs,
+			CreatedAt:  a.CreatedAt,
+		})
+	}
+	return WebhookDeliveryDetailResponse{
+		WebhookDeliveryResponse: NewWebhookDeliveryResponse(delivery),
+		Payload:                 rawJSON(delivery.Payload),
+		AttemptLog:              attempts,
+	}
+}
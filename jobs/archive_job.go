@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/services"
+)
+
+// ArchiveJob periodically moves vouchers for flights older than Retention
+// into the archive table.
+type ArchiveJob struct {
+	Service   *services.VoucherService
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+func NewArchiveJob(service *services.VoucherService, retention, interval time.Duration) *ArchiveJob {
+	return &ArchiveJob{
+		Service:   service,
+		Retention: retention,
+		Interval:  interval,
+	}
+}
+
+// Run archives once immediately and then every Interval until ctx is done.
+func (j *ArchiveJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		j.archive(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *ArchiveJob) archive(ctx context.Context) {
+	archived, err := j.Service.ArchiveVouchers(ctx, j.Retention)
+	if err != nil && ctx.Err() != nil {
+		logging.For("jobs").InfoContext(ctx, "archiving interrupted by shutdown", "vouchers", archived)
+		return
+	}
+	if err != nil {
+		logging.For("jobs").ErrorContext(ctx, "failed to archive vouchers", "error", err)
+		return
+	}
+	if archived > 0 {
+		logging.For("jobs").InfoContext(ctx, "archived vouchers", "vouchers", archived, "retention", j.Retention.String())
+	}
+}
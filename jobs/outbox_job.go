@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/services"
+)
+
+// outboxPruneInterval is how often relayed events past their retention are
+// removed from the outbox.
+const outboxPruneInterval = time.Hour
+
+// OutboxRelayJob relays outbox events to the event stream and removes them
+// from the outbox once they are older than Retention.
+type OutboxRelayJob struct {
+	Service   *services.OutboxService
+	Interval  time.Duration
+	Retention time.Duration
+}
+
+func NewOutboxRelayJob(service *services.OutboxService, interval, retention time.Duration) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		Service:   service,
+		Interval:  interval,
+		Retention: retention,
+	}
+}
+
+// Run relays once immediately and then every Interval until ctx is done.
+func (j *OutboxRelayJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	var pruned time.Time
+	for {
+		j.relay(ctx)
+		if time.Since(pruned) >= outboxPruneInterval {
+			j.prune(ctx)
+			pruned = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *OutboxRelayJob) relay(ctx context.Context) {
+	relayed, err := j.Service.Relay(ctx)
+	if err != nil && ctx.Err() != nil {
+		logging.For("jobs").InfoContext(ctx, "event relay interrupted by shutdown", "relayed", relayed)
+		return
+	}
+	if err != nil {
+		logging.For("jobs").ErrorContext(ctx, "failed to relay events", "relayed", relayed, "error", err)
+	}
+}
+
+func (j *OutboxRelayJob) prune(ctx context.Context) {
+	removed, err := j.Service.PruneOutbox(ctx, j.Retention)
+	if err != nil && ctx.Err() == nil {
+		logging.For("jobs").ErrorContext(ctx, "failed to prune outbox", "error", err)
+		return
+	}
+	if removed > 0 {
+		logging.For("jobs").InfoContext(ctx, "pruned outbox", "events", removed, "retention", j.Retention.String())
+	}
+}
@@ -0,0 +1,51 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/services"
+)
+
+// WebhookJob delivers queued webhook events, retrying failed deliveries
+// once their backoff has passed.
+type WebhookJob struct {
+	Service  *services.WebhookService
+	Interval time.Duration
+}
+
+func NewWebhookJob(service *services.WebhookService, interval time.Duration) *WebhookJob {
+	return &WebhookJob{
+		Service:  service,
+		Interval: interval,
+	}
+}
+
+// Run delivers what is due once immediately and then every Interval until
+// ctx is done.
+func (j *WebhookJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		j.deliver(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *WebhookJob) deliver(ctx context.Context) {
+	attempted, err := j.Service.DeliverDue(ctx)
+	if err != nil && ctx.Err() != nil {
+		logging.For("jobs").InfoContext(ctx, "webhook delivery interrupted by shutdown", "deliveries", attempted)
+		return
+	}
+	if err != nil {
+		logging.For("jobs").ErrorContext(ctx, "failed to deliver webhooks", "error", err)
+	}
+}
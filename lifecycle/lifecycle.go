@@ -0,0 +1,144 @@
+// Package lifecycle runs the HTTP server alongside background workers and
+// shuts everything down in order when the process is asked to stop.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+)
+
+// Hook releases a resource during shutdown.
+type Hook func(ctx context.Context) error
+
+type namedHook struct {
+	name string
+	hook Hook
+}
+
+// Manager tracks the background workers and shutdown hooks of the process.
+type Manager struct {
+	// ShutdownTimeout is the drain deadline shared by in-flight requests,
+	// workers and hooks once shutdown starts.
+	ShutdownTimeout time.Duration
+
+	workerCtx    context.Context
+	stopWorkers  context.CancelFunc
+	workers      sync.WaitGroup
+	mu           sync.Mutex
+	hooks        []namedHook
+	shuttingDown bool
+}
+
+func NewManager(shutdownTimeout time.Duration) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		ShutdownTimeout: shutdownTimeout,
+		workerCtx:       ctx,
+		stopWorkers:     cancel,
+	}
+}
+
+// Go runs worker in the background. Its context is cancelled once the HTTP
+// server has drained, and shutdown waits for it to return.
+func (m *Manager) Go(name string, worker func(ctx context.Context)) {
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		worker(m.workerCtx)
+		logging.For("lifecycle").Debug("worker stopped", "worker", name)
+	}()
+}
+
+// OnShutdown registers hook to run after the server and workers have
+// stopped. Hooks run in reverse order of registration, so a resource is
+// released before the ones it was built on.
+func (m *Manager) OnShutdown(name string, hook Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, namedHook{name: name, hook: hook})
+}
+
+// Run serves srv until SIGINT or SIGTERM arrives or the server fails, then
+// shuts down: it stops accepting connections and waits for in-flight
+// requests, stops the workers and runs the shutdown hooks, all within
+// ShutdownTimeout.
+func (m *Manager) Run(srv *http.Server) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logging.For("lifecycle").Info("server starting", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+		close(serveErr)
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+		logging.For("lifecycle").Info("shutdown requested", "timeout", m.ShutdownTimeout.String())
+	case err = <-serveErr:
+		logging.For("lifecycle").Error("server failed", "error", err)
+	}
+	// A second signal kills the process instead of waiting for the drain.
+	stop()
+
+	return errors.Join(err, m.Shutdown(srv))
+}
+
+// Shutdown stops srv, the workers and then runs the hooks. It is called by
+// Run and only has an effect the first time.
+func (m *Manager) Shutdown(srv *http.Server) error {
+	m.mu.Lock()
+	if m.shuttingDown {
+		m.mu.Unlock()
+		return nil
+	}
+	m.shuttingDown = true
+	hooks := m.hooks
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.ShutdownTimeout)
+	defer cancel()
+	log := logging.For("lifecycle")
+
+	var errs []error
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Error("failed to drain requests", "error", err)
+		errs = append(errs, err)
+	}
+
+	m.stopWorkers()
+	done := make(chan struct{})
+	go func() {
+		m.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Error("workers did not stop before the deadline")
+		errs = append(errs, ctx.Err())
+	}
+
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].hook(ctx); err != nil {
+			log.Error("shutdown hook failed", "hook", hooks[i].name, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		log.Debug("shutdown hook done", "hook", hooks[i].name)
+	}
+
+	log.Info("shutdown complete")
+	return errors.Join(errs...)
+}
@@ -0,0 +1,160 @@
+// Package logging configures structured logging with log/slog. Every record
+// carries the module that wrote it and, when logged with a request context,
+// the request and trace IDs. Fields holding crew PII are redacted.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redacted replaces the value of redacted fields.
+const Redacted = "[REDACTED]"
+
+// Options configures the loggers returned by For.
+type Options struct {
+	// Level is the minimum level logged by modules without their own level.
+	Level slog.Level
+	// ModuleLevels overrides Level per module, e.g. {"gorm": slog.LevelWarn}.
+	ModuleLevels map[string]slog.Level
+	// Format is "json" (default) or "text".
+	Format string
+	// RedactFields lists attribute keys whose values are never written.
+	RedactFields []string
+	// Output defaults to standard output.
+	Output io.Writer
+}
+
+type state struct {
+	handler      slog.Handler
+	level        slog.Level
+	moduleLevels map[string]slog.Level
+}
+
+var current atomic.Pointer[state]
+
+func init() {
+	Setup(Options{RedactFields: []string{"crew_name"}})
+}
+
+// Setup replaces the logging configuration. Loggers returned by For before
+// the call keep the configuration they were created with.
+func Setup(opts Options) {
+	output := opts.Output
+	if output == nil {
+		output = os.Stdout
+	}
+
+	redact := make(map[string]bool, len(opts.RedactFields))
+	for _, field := range opts.RedactFields {
+		redact[field] = true
+	}
+	handlerOpts := &slog.HandlerOptions{
+		// Filtering happens per module in moduleHandler.
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if redact[a.Key] {
+				return slog.String(a.Key, Redacted)
+			}
+			return a
+		},
+	}
+
+	var handler slog.Handler
+	if opts.Format == "text" {
+		handler = slog.NewTextHandler(output, handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(output, handlerOpts)
+	}
+
+	current.Store(&state{
+		handler:      contextHandler{handler},
+		level:        opts.Level,
+		moduleLevels: opts.ModuleLevels,
+	})
+	slog.SetDefault(For("app"))
+}
+
+// For returns the logger for a module, logging at the module's level.
+func For(module string) *slog.Logger {
+	s := current.Load()
+	level, ok := s.moduleLevels[module]
+	if !ok {
+		level = s.level
+	}
+	return slog.New(moduleHandler{
+		Handler: s.handler.WithAttrs([]slog.Attr{slog.String("module", module)}),
+		level:   level,
+	})
+}
+
+// ParseModuleLevels parses per-module levels written as
+// "gorm=warn,services=debug".
+func ParseModuleLevels(value string) (map[string]slog.Level, error) {
+	levels := map[string]slog.Level{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		module, name, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid module level %q, expected module=level", pair)
+		}
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("invalid level for module %s: %w", module, err)
+		}
+		levels[strings.TrimSpace(module)] = level
+	}
+	return levels, nil
+}
+
+// moduleHandler drops records below the module's level.
+type moduleHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (h moduleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h moduleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return moduleHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h moduleHandler) WithGroup(name string) slog.Handler {
+	return moduleHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// contextHandler adds the request and trace IDs carried by the context.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,14 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+)
+
+// RedisLogger writes go-redis's internal logs through the "redis" module
+// logger. Install it with redis.SetLogger.
+type RedisLogger struct{}
+
+func (RedisLogger) Printf(ctx context.Context, format string, v ...interface{}) {
+	For("redis").WarnContext(ctx, fmt.Sprintf(format, v...))
+}
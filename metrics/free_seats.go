@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// FlightSeats is the number of seats still free on one flight.
+type FlightSeats struct {
+	FlightNumber string
+	FlightDate   string
+	AircraftType string
+	Free         int
+}
+
+var freeSeatsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "flight", "free_seats"),
+	"Seats not yet assigned to a voucher, per active flight.",
+	[]string{"flight_number", "flight_date", "aircraft_type"}, nil,
+)
+
+// freeSeatsTimeout bounds the query run on every scrape.
+const freeSeatsTimeout = 5 * time.Second
+
+// FreeSeatsCollector reports free seats per active flight, read from source
+// on every scrape so the gauge never goes stale.
+type FreeSeatsCollector struct {
+	source func(ctx context.Context) ([]FlightSeats, error)
+}
+
+func NewFreeSeatsCollector(source func(ctx context.Context) ([]FlightSeats, error)) *FreeSeatsCollector {
+	return &FreeSeatsCollector{source: source}
+}
+
+func (c *FreeSeatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- freeSeatsDesc
+}
+
+func (c *FreeSeatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), freeSeatsTimeout)
+	defer cancel()
+
+	flights, err := c.source(ctx)
+	if err != nil {
+		logging.For("metrics").Error("failed to collect free seats", "error", err)
+		return
+	}
+	for _, f := range flights {
+		ch <- prometheus.MustNewConstMetric(freeSeatsDesc, prometheus.GaugeValue, float64(f.Free), f.FlightNumber, f.FlightDate, f.AircraftType)
+	}
+}
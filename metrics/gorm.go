@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const startTimeKey = "metrics:start_time"
+
+// GormPlugin records the duration of every statement in DBQueryDuration.
+type GormPlugin struct{}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	// Time every statement, whichever GORM API issued it.
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("metrics:before_create", start),
+		callback.Create().After("gorm:create").Register("metrics:after_create", observe("create")),
+		callback.Query().Before("gorm:query").Register("metrics:before_query", start),
+		callback.Query().After("gorm:query").Register("metrics:after_query", observe("query")),
+		callback.Update().Before("gorm:update").Register("metrics:before_update", start),
+		callback.Update().After("gorm:update").Register("metrics:after_update", observe("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:before_delete", start),
+		callback.Delete().After("gorm:delete").Register("metrics:after_delete", observe("delete")),
+		callback.Row().Before("gorm:row").Register("metrics:before_row", start),
+		callback.Row().After("gorm:row").Register("metrics:after_row", observe("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:before_raw", start),
+		callback.Raw().After("gorm:raw").Register("metrics:after_raw", observe("raw")),
+	)
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		started, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(started).Seconds())
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"VSA_GOGIN_BE/audit"
+	"VSA_GOGIN_BE/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnonymousActor is recorded when a request does not identify its caller.
+const AnonymousActor = "anonymous"
+
+// AuditMetadata attaches the caller's identity to the request context so
+// services can attribute audit log entries. The actor is read from the
+// X-Actor header and the request ID is the one set by RequestID.
+func AuditMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := c.GetHeader("X-Actor")
+		if actor == "" {
+			actor = AnonymousActor
+		}
+
+		ctx := audit.WithMetadata(c.Request.Context(), audit.Metadata{
+			Actor:      actor,
+			RequestID:  logging.RequestID(c.Request.Context()),
+			RemoteAddr: c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"VSA_GOGIN_BE/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBodyBytes caps the request bodies middleware reads ahead of the handler.
+// The bodies of the routes using them are a few hundred bytes.
+const maxBodyBytes = 1 << 20
+
+// peekBody reads the request body and puts it back for the handler. Bodies
+// over maxBodyBytes abort the request with 413 Request Entity Too Large, and
+// other read errors with 400 Bad Request; ok is false then.
+func peekBody(c *gin.Context) (body []byte, ok bool) {
+	if c.Request.Body == nil {
+		return nil, true
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, dto.NewErrorResponse(c.Request.Context(), "request body must be at most 1 MiB"))
+		return nil, false
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, dto.NewErrorResponse(c.Request.Context(), err.Error()))
+		return nil, false
+	}
+	return body, true
+}
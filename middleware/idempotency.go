@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client's key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from the store.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	idempotencyLockTTL      = 30 * time.Second
+
+	// statusClientClosedRequest is the status of responses to requests whose
+	// client hung up, see controllers.respondError.
+	statusClientClosedRequest = 499
+)
+
+// IdempotentResponse is the stored outcome of the first request made with a key.
+type IdempotentResponse struct {
+	RequestHash string `json:"request_hash"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// IdempotencyStore keeps responses per idempotency key.
+type IdempotencyStore interface {
+	// Get returns the stored response, or nil when the key is unknown.
+	Get(ctx context.Context, key string) (*IdempotentResponse, error)
+	Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error
+	// Lock marks the key as in flight and reports false when another request
+	// holds it already.
+	Lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Unlock(ctx context.Context, key string) error
+}
+
+// RedisIdempotencyStore keeps idempotent responses in Redis so every replica
+// sees them.
+type RedisIdempotencyStore struct {
+	RDB *redis.Client
+}
+
+func NewRedisIdempotencyStore(rdb *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{RDB: rdb}
+}
+
+func (s *RedisIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	data, err := s.RDB.Get(ctx, "idempotency:"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var response IdempotentResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (s *RedisIdempotencyStore) Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return s.RDB.Set(ctx, "idempotency:"+key, data, ttl).Err()
+}
+
+func (s *RedisIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.RDB.SetNX(ctx, "idempotency_lock:"+key, 1, ttl).Result()
+}
+
+func (s *RedisIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	return s.RDB.Del(ctx, "idempotency_lock:"+key).Err()
+}
+
+// Idempotency replays the original response when a request is retried with
+// the same Idempotency-Key header, so clients on flaky networks can safely
+// retry. Requests without the header pass through unchanged. Only responses
+// a retry would get again are stored, see storable; the others can be
+// retried for real.
+func Idempotency(store IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, dto.NewErrorResponse(c.Request.Context(), "Idempotency-Key must be at most 255 characters"))
+			return
+		}
+
+		body, ok := peekBody(c)
+		if !ok {
+			return
+		}
+
+		hash := sha256.Sum256(body)
+		requestHash := hex.EncodeToString(hash[:])
+		// Keys are only unique per client, so another client reusing one
+		// must not get this client's response.
+		storeKey := c.Request.Method + ":" + c.FullPath() + ":" + idempotencyClient(c) + ":" + key
+		ctx := c.Request.Context()
+
+		stored, err := store.Get(ctx, storeKey)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, dto.NewErrorResponse(c.Request.Context(), "idempotency store unavailable"))
+			return
+		}
+		if stored != nil {
+			if stored.RequestHash != requestHash {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, dto.NewErrorResponse(c.Request.Context(), "Idempotency-Key was already used with a different request body"))
+				return
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(stored.Status, stored.ContentType, stored.Body)
+			c.Abort()
+			return
+		}
+
+		locked, err := store.Lock(ctx, storeKey, idempotencyLockTTL)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, dto.NewErrorResponse(c.Request.Context(), "idempotency store unavailable"))
+			return
+		}
+		if !locked {
+			c.AbortWithStatusJSON(http.StatusConflict, dto.NewErrorResponse(c.Request.Context(), "a request with this Idempotency-Key is still in progress"))
+			return
+		}
+		defer store.Unlock(context.WithoutCancel(ctx), storeKey)
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if !storable(status) {
+			return
+		}
+		response := &IdempotentResponse{
+			RequestHash: requestHash,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Save(context.WithoutCancel(ctx), storeKey, response, ttl); err != nil {
+			logging.For("idempotency").ErrorContext(ctx, "failed to store idempotent response", "error", err)
+		}
+	}
+}
+
+// idempotencyClient identifies the caller by the hash of its X-API-Key header,
+// which keeps the key itself out of the store, or else by its IP.
+func idempotencyClient(c *gin.Context) string {
+	if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+		hash := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(hash[:])
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// storable reports whether a response with the status is final: successes
+// and client errors caused by the request itself. Server errors, timeouts,
+// rate limiting and requests the client hung up on say nothing about the
+// outcome of a retry.
+func storable(status int) bool {
+	switch {
+	case status >= http.StatusOK && status < http.StatusMultipleChoices:
+		return true
+	case status == http.StatusRequestTimeout, status == http.StatusTooManyRequests, status == statusClientClosedRequest:
+		return false
+	default:
+		return status >= http.StatusBadRequest && status < http.StatusInternalServerError
+	}
+}
+
+// bodyRecorder copies everything written to the response so it can be stored.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// memoryIdempotencyStore keeps responses in a map.
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]*IdempotentResponse
+	locks     map[string]bool
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{responses: map[string]*IdempotentResponse{}, locks: map[string]bool{}}
+}
+
+func (s *memoryIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.responses[key], nil
+}
+
+func (s *memoryIdempotencyStore) Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = response
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.locks[key] {
+		return false, nil
+	}
+	s.locks[key] = true
+	return true, nil
+}
+
+func (s *memoryIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.locks, key)
+	return nil
+}
+
+func TestIdempotencyStoresOnlyFinalResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		status int
+		stored bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusCreated, true},
+		{http.StatusBadRequest, true},
+		{http.StatusNotFound, true},
+		{http.StatusConflict, true},
+		{http.StatusRequestTimeout, false},
+		{http.StatusTooManyRequests, false},
+		{statusClientClosedRequest, false},
+		{http.StatusInternalServerError, false},
+		{http.StatusServiceUnavailable, false},
+		{http.StatusGatewayTimeout, false},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			calls := 0
+			router := gin.New()
+			router.POST("/generate", Idempotency(newMemoryIdempotencyStore(), time.Hour), func(c *gin.Context) {
+				calls++
+				c.String(tt.status, "call %d", calls)
+			})
+
+			var last *httptest.ResponseRecorder
+			for i := 0; i < 2; i++ {
+				last = httptest.NewRecorder()
+				req := httptest.NewRequest("POST", "/generate", strings.NewReader(`{"crew_id":"A1"}`))
+				req.Header.Set(IdempotencyKeyHeader, "key-1")
+				router.ServeHTTP(last, req)
+			}
+
+			replayed := last.Header().Get(IdempotentReplayedHeader) == "true"
+			if replayed != tt.stored {
+				t.Errorf("retry replayed = %v, want %v", replayed, tt.stored)
+			}
+			wantCalls := 2
+			if tt.stored {
+				wantCalls = 1
+			}
+			if calls != wantCalls {
+				t.Errorf("handler ran %d times, want %d", calls, wantCalls)
+			}
+			if last.Code != tt.status || (tt.stored && last.Body.String() != "call 1") {
+				t.Errorf("retry = %d %q, want %d", last.Code, last.Body.String(), tt.status)
+			}
+		})
+	}
+}
+
+func TestIdempotencyRejectsReusedKeyWithDifferentBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/generate", Idempotency(newMemoryIdempotencyStore(), time.Hour), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for i, body := range []string{`{"crew_id":"A1"}`, `{"crew_id":"B2"}`} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/generate", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		router.ServeHTTP(w, req)
+		if want := []int{http.StatusOK, http.StatusUnprocessableEntity}[i]; w.Code != want {
+			t.Errorf("request %d = %d, want %d", i+1, w.Code, want)
+		}
+	}
+}
+
+func TestIdempotencyKeysAreScopedToTheClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	calls := 0
+	router.POST("/generate", Idempotency(newMemoryIdempotencyStore(), time.Hour), func(c *gin.Context) {
+		calls++
+		c.String(http.StatusOK, "call "+strconv.Itoa(calls))
+	})
+
+	tests := []struct {
+		name   string
+		apiKey string
+		ip     string
+		want   string
+	}{
+		{"first client", "key-a", "10.0.0.1", "call 1"},
+		{"same client retrying", "key-a", "10.0.0.1", "call 1"},
+		{"other api key", "key-b", "10.0.0.1", "call 2"},
+		{"same api key from another ip", "key-a", "10.0.0.2", "call 1"},
+		{"no api key", "", "10.0.0.1", "call 3"},
+		{"no api key from another ip", "", "10.0.0.2", "call 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/generate", strings.NewReader(`{"crew_id":"A1"}`))
+			req.RemoteAddr = tt.ip + ":1234"
+			req.Header.Set(IdempotencyKeyHeader, "key-1")
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			router.ServeHTTP(w, req)
+			if w.Body.String() != tt.want {
+				t.Errorf("response = %q, want %q", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestOversizedBodiesAreRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/idempotent", Idempotency(newMemoryIdempotencyStore(), time.Hour), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/limited", RateLimit(NewMemoryRateLimitStore(), RateLimitByCrewID(5, time.Minute)), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	body := `{"crew_id":"A1","padding":"` + strings.Repeat("x", maxBodyBytes) + `"}`
+	for _, path := range []string{"/idempotent", "/limited"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("POST %s = %d, want %d", path, w.Code, http.StatusRequestEntityTooLarge)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"VSA_GOGIN_BE/dto"
+	"VSA_GOGIN_BE/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// APIKeyHeader identifies the calling client for per-key rate limits.
+	APIKeyHeader = "X-API-Key"
+
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimitResult is the state of one rate limit window after a hit.
+type RateLimitResult struct {
+	Count   int64
+	ResetIn time.Duration
+}
+
+// RateLimitStore counts hits per key in fixed windows.
+type RateLimitStore interface {
+	// Hit counts one request for key and returns the count in the current
+	// window, starting a new window when none is open.
+	Hit(ctx context.Context, key string, window time.Duration) (RateLimitResult, error)
+}
+
+// hitScript increments the counter and starts its window on the first hit,
+// atomically so concurrent replicas agree on the count.
+var hitScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// RedisRateLimitStore counts hits in Redis so limits hold across replicas.
+type RedisRateLimitStore struct {
+	RDB *redis.Client
+}
+
+func NewRedisRateLimitStore(rdb *redis.Client) *RedisRateLimitStore {
+	return &RedisRateLimitStore{RDB: rdb}
+}
+
+func (s *RedisRateLimitStore) Hit(ctx context.Context, key string, window time.Duration) (RateLimitResult, error) {
+	values, err := hitScript.Run(ctx, s.RDB, []string{"ratelimit:" + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	resetIn := time.Duration(values[1]) * time.Millisecond
+	if resetIn < 0 {
+		resetIn = window
+	}
+	return RateLimitResult{Count: values[0], ResetIn: resetIn}, nil
+}
+
+// MemoryRateLimitStore counts hits in process. Limits only hold per replica,
+// so it is meant as a fallback while Redis is unreachable.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	windows   map[string]*memoryWindow
+	nextSweep time.Time
+}
+
+type memoryWindow struct {
+	count   int64
+	resetAt time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{windows: make(map[string]*memoryWindow)}
+}
+
+func (s *MemoryRateLimitStore) Hit(ctx context.Context, key string, window time.Duration) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.After(s.nextSweep) {
+		for k, w := range s.windows {
+			if !now.Before(w.resetAt) {
+				delete(s.windows, k)
+			}
+		}
+		s.nextSweep = now.Add(time.Minute)
+	}
+
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &memoryWindow{resetAt: now.Add(window)}
+		s.windows[key] = w
+	}
+	w.count++
+	return RateLimitResult{Count: w.count, ResetIn: w.resetAt.Sub(now)}, nil
+}
+
+// FallbackRateLimitStore uses Primary and switches to Fallback while Primary
+// fails, so a Redis outage does not take rate limiting down with it. Primary
+// is retried at most every fallbackRetryInterval to keep requests fast
+// during the outage.
+type FallbackRateLimitStore struct {
+	Primary  RateLimitStore
+	Fallback RateLimitStore
+	// retryAt is when Primary is tried again, in Unix nanoseconds; zero
+	// while Primary is healthy.
+	retryAt atomic.Int64
+}
+
+const fallbackRetryInterval = 5 * time.Second
+
+func NewFallbackRateLimitStore(primary, fallback RateLimitStore) *FallbackRateLimitStore {
+	return &FallbackRateLimitStore{Primary: primary, Fallback: fallback}
+}
+
+func (s *FallbackRateLimitStore) Hit(ctx context.Context, key string, window time.Duration) (RateLimitResult, error) {
+	retryAt := s.retryAt.Load()
+	if retryAt != 0 && time.Now().UnixNano() < retryAt {
+		return s.Fallback.Hit(ctx, key, window)
+	}
+
+	result, err := s.Primary.Hit(ctx, key, window)
+	if err == nil {
+		if s.retryAt.Swap(0) != 0 {
+			logging.For("ratelimit").InfoContext(ctx, "rate limit store recovered, counting in Redis again")
+		}
+		return result, nil
+	}
+
+	if s.retryAt.Swap(time.Now().Add(fallbackRetryInterval).UnixNano()) == 0 {
+		logging.For("ratelimit").WarnContext(ctx, "rate limit store unavailable, counting in memory", "error", err)
+	}
+	return s.Fallback.Hit(ctx, key, window)
+}
+
+// RateLimitRule limits requests sharing the same key. Rules whose Limit is
+// zero or whose Key returns "" for a request are skipped.
+type RateLimitRule struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+	Key    func(c *gin.Context) string
+}
+
+// RateLimitByIP limits requests per client IP.
+func RateLimitByIP(limit int, window time.Duration) RateLimitRule {
+	return RateLimitRule{Name: "ip", Limit: limit, Window: window, Key: func(c *gin.Context) string {
+		return c.ClientIP()
+	}}
+}
+
+// RateLimitByAPIKey limits requests per X-API-Key header value.
+func RateLimitByAPIKey(limit int, window time.Duration) RateLimitRule {
+	return RateLimitRule{Name: "api_key", Limit: limit, Window: window, Key: func(c *gin.Context) string {
+		return c.GetHeader(APIKeyHeader)
+	}}
+}
+
+// RateLimitByCrewID limits requests per crew_id in the JSON request body.
+func RateLimitByCrewID(limit int, window time.Duration) RateLimitRule {
+	return RateLimitRule{Name: "crew_id", Limit: limit, Window: window, Key: crewIDFromBody}
+}
+
+// crewIDFromBody peeks at the crew_id of a JSON body and puts the body back
+// for the handler. Bodies too large to read abort the request, see peekBody.
+func crewIDFromBody(c *gin.Context) string {
+	body, ok := peekBody(c)
+	if !ok {
+		return ""
+	}
+
+	var payload struct {
+		CrewID string `json:"crew_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.CrewID
+}
+
+// RateLimit rejects requests with 429 Too Many Requests once any rule's limit
+// is reached within its window. Responses carry X-RateLimit-* headers for the
+// rule closest to its limit, plus Retry-After when rejected. Requests are let
+// through when the store fails, so an outage never blocks generation.
+func RateLimit(store RateLimitStore, rules ...RateLimitRule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var tightest *rateLimitState
+		for _, rule := range rules {
+			if rule.Limit <= 0 {
+				continue
+			}
+			key := rule.Key(c)
+			if c.IsAborted() {
+				return
+			}
+			if key == "" {
+				continue
+			}
+
+			result, err := store.Hit(c.Request.Context(), c.FullPath()+":"+rule.Name+":"+key, rule.Window)
+			if err != nil {
+				logging.For("ratelimit").ErrorContext(c.Request.Context(), "rate limit check failed", "error", err)
+				continue
+			}
+
+			state := &rateLimitState{rule: rule, result: result}
+			if state.exceeded() {
+				tightest = state
+				break
+			}
+			if tightest == nil || state.remaining() < tightest.remaining() {
+				tightest = state
+			}
+		}
+		if tightest == nil {
+			c.Next()
+			return
+		}
+
+		resetSeconds := strconv.Itoa(int(math.Ceil(tightest.result.ResetIn.Seconds())))
+		c.Header(RateLimitLimitHeader, strconv.Itoa(tightest.rule.Limit))
+		c.Header(RateLimitRemainingHeader, strconv.FormatInt(tightest.remaining(), 10))
+		c.Header(RateLimitResetHeader, resetSeconds)
+
+		if tightest.exceeded() {
+			c.Header("Retry-After", resetSeconds)
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, dto.NewErrorResponse(c.Request.Context(),
+				fmt.Sprintf("rate limit exceeded for %s, retry in %s seconds", tightest.rule.Name, resetSeconds),
+			))
+			return
+		}
+		c.Next()
+	}
+}
+
+type rateLimitState struct {
+	rule   RateLimitRule
+	result RateLimitResult
+}
+
+func (s *rateLimitState) remaining() int64 {
+	return max(int64(s.rule.Limit)-s.result.Count, 0)
+}
+
+func (s *rateLimitState) exceeded() bool {
+	return s.result.Count > int64(s.rule.Limit)
+}
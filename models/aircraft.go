@@ -7,17 +7,50 @@ import (
 )
 
 type Aircraft struct {
-	ID              uint   `json:"id" gorm:"primaryKey"`
-	AircraftType    string `json:"aircraft_type" gorm:"unique;not null"`
-	AircraftTypeKey string `json:"aircraft_type_key" gorm:"unique;not null"`
-	NumRows         int    `json:"num_rows"`
-	SeatsPerRow     string `json:"seats_per_row"` // Comma-separated seat letters, e.g. "A,C,D,F"
+	ID              uint           `json:"id" gorm:"primaryKey"`
+	AircraftType    string         `json:"aircraft_type" gorm:"unique;not null"`
+	AircraftTypeKey string         `json:"aircraft_type_key" gorm:"unique;not null"` // Set once on create and never changed by renames
+	NumRows         int            `json:"num_rows"`
+	SeatsPerRow     string         `json:"seats_per_row"` // Seat letters per row with aisle markers, e.g. "ABC-DEF"
+	BlockedSeats    string         `json:"blocked_seats"` // Comma-separated seats never assigned, e.g. "1A,1F"
+	DeletedAt       gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
 
-// BeforeSave hook — automatically set AircraftTypeKey before saving
-func (a *Aircraft) BeforeSave(tx *gorm.DB) (err error) {
-	key := strings.ToLower(a.AircraftType)
-	key = strings.ReplaceAll(key, " ", "_")
-	a.AircraftTypeKey = key
-	return
+// SeatCount returns the number of seats on the aircraft.
+func (a Aircraft) SeatCount() int {
+	return a.NumRows * len(a.SeatLetters())
+}
+
+// AssignableSeatCount returns the number of seats vouchers can be issued for,
+// i.e. every seat except the blocked ones.
+func (a Aircraft) AssignableSeatCount() int {
+	return a.SeatCount() - len(a.BlockedSeatList())
+}
+
+// BlockedSeatList returns the blocked seats, e.g. ["1A", "1F"].
+func (a Aircraft) BlockedSeatList() []string {
+	seats := []string{}
+	for _, seat := range strings.Split(a.BlockedSeats, ",") {
+		if seat = strings.TrimSpace(seat); seat != "" {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
+}
+
+// IsBlocked reports whether the seat is blocked.
+func (a Aircraft) IsBlocked(seat string) bool {
+	for _, blocked := range a.BlockedSeatList() {
+		if blocked == seat {
+			return true
+		}
+	}
+	return false
+}
+
+// AircraftTypeKeyFor derives the aircraft type key from its display name,
+// e.g. "Airbus A320" becomes "airbus_a320".
+func AircraftTypeKeyFor(aircraftType string) string {
+	key := strings.ToLower(aircraftType)
+	return strings.ReplaceAll(key, " ", "_")
 }
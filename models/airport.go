@@ -0,0 +1,95 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// airportTimezones maps IATA airport codes to the IANA time zone used to
+// work out the local departure date of flights leaving them.
+var airportTimezones = map[string]string{
+	// Indonesia — Western Indonesia Time (WIB)
+	"CGK": "Asia/Jakarta", "HLP": "Asia/Jakarta", "BDO": "Asia/Jakarta",
+	"KJT": "Asia/Jakarta", "SRG": "Asia/Jakarta", "SOC": "Asia/Jakarta",
+	"JOG": "Asia/Jakarta", "YIA": "Asia/Jakarta", "SUB": "Asia/Jakarta",
+	"MLG": "Asia/Jakarta", "KNO": "Asia/Jakarta", "PDG": "Asia/Jakarta",
+	"PKU": "Asia/Jakarta", "BTH": "Asia/Jakarta", "PLM": "Asia/Jakarta",
+	"PGK": "Asia/Jakarta", "TKG": "Asia/Jakarta", "BTJ": "Asia/Jakarta",
+	"DJB": "Asia/Jakarta", "BKS": "Asia/Jakarta", "TNJ": "Asia/Jakarta",
+	"PNK": "Asia/Pontianak",
+	// Indonesia — Central Indonesia Time (WITA)
+	"DPS": "Asia/Makassar", "LOP": "Asia/Makassar", "UPG": "Asia/Makassar",
+	"BPN": "Asia/Makassar", "BDJ": "Asia/Makassar", "MDC": "Asia/Makassar",
+	"KOE": "Asia/Makassar", "LBJ": "Asia/Makassar", "KDI": "Asia/Makassar",
+	"PLW": "Asia/Makassar", "TRK": "Asia/Makassar", "SRI": "Asia/Makassar",
+	// Indonesia — Eastern Indonesia Time (WIT)
+	"DJJ": "Asia/Jayapura", "AMQ": "Asia/Jayapura", "SOQ": "Asia/Jayapura",
+	"TIM": "Asia/Jayapura", "MKQ": "Asia/Jayapura", "BIK": "Asia/Jayapura",
+	"TTE": "Asia/Jayapura",
+	// Asia Pacific
+	"SIN": "Asia/Singapore", "KUL": "Asia/Kuala_Lumpur", "PEN": "Asia/Kuala_Lumpur",
+	"BKK": "Asia/Bangkok", "DMK": "Asia/Bangkok", "MNL": "Asia/Manila",
+	"SGN": "Asia/Ho_Chi_Minh", "HAN": "Asia/Ho_Chi_Minh", "HKG": "Asia/Hong_Kong",
+	"TPE": "Asia/Taipei", "PVG": "Asia/Shanghai", "PEK": "Asia/Shanghai",
+	"CAN": "Asia/Shanghai", "ICN": "Asia/Seoul", "NRT": "Asia/Tokyo",
+	"HND": "Asia/Tokyo", "KIX": "Asia/Tokyo", "DEL": "Asia/Kolkata",
+	"BOM": "Asia/Kolkata", "DIL": "Asia/Dili",
+	"SYD": "Australia/Sydney", "MEL": "Australia/Melbourne", "BNE": "Australia/Brisbane",
+	"PER": "Australia/Perth", "DRW": "Australia/Darwin", "ADL": "Australia/Adelaide",
+	"AKL": "Pacific/Auckland",
+	// Middle East, Europe and the Americas
+	"DXB": "Asia/Dubai", "AUH": "Asia/Dubai", "DOH": "Asia/Qatar",
+	"JED": "Asia/Riyadh", "MED": "Asia/Riyadh", "RUH": "Asia/Riyadh",
+	"IST": "Europe/Istanbul", "LHR": "Europe/London", "AMS": "Europe/Amsterdam",
+	"CDG": "Europe/Paris", "FRA": "Europe/Berlin",
+	"JFK": "America/New_York", "LAX": "America/Los_Angeles",
+}
+
+// DefaultTimezone is used for flights whose departure airport is not given.
+// It is replaced at startup from configuration.
+var DefaultTimezone = "Asia/Jakarta"
+
+// KnownAirport reports whether the airport's time zone is known.
+func KnownAirport(code string) bool {
+	_, ok := airportTimezones[code]
+	return ok
+}
+
+// AirportLocation returns the time zone of the departure airport, falling
+// back to DefaultTimezone when code is empty.
+func AirportLocation(code string) (*time.Location, error) {
+	name := DefaultTimezone
+	if code != "" {
+		var ok bool
+		if name, ok = airportTimezones[code]; !ok {
+			return nil, fmt.Errorf("unknown airport %q", code)
+		}
+	}
+	return time.LoadLocation(name)
+}
+
+// FlightDateFor normalizes a client supplied flight date to the local
+// departure date. Plain "YYYY-MM-DD" values are taken as they are; RFC 3339
+// timestamps are converted to the departure airport's time zone first, so
+// "2025-11-30T20:00:00Z" leaving CGK is a flight on 2025-12-01.
+func FlightDateFor(value, departureAirport string) (Date, error) {
+	if date, err := ParseDate(value); err == nil {
+		return date, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid flight date %q, expected YYYY-MM-DD or RFC 3339", value)
+	}
+	return FlightDateAt(t, departureAirport)
+}
+
+// FlightDateAt returns the local date at the departure airport of a flight
+// leaving at t.
+func FlightDateAt(t time.Time, departureAirport string) (Date, error) {
+	loc, err := AirportLocation(departureAirport)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t.In(loc)), nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// ArchivedVoucher is a voucher moved out of the vouchers table by the
+// archival job once its flight is older than the retention period. It keeps
+// the original voucher ID.
+type ArchivedVoucher struct {
+	ID               uint       `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	CrewName         string     `json:"crew_name"`
+	CrewID           string     `json:"crew_id"`
+	CrewBase         string     `json:"crew_base"`
+	FlightNumber     string     `json:"flight_number"`
+	FlightDate       Date       `json:"flight_date" gorm:"index"`
+	DepartureAirport string     `json:"departure_airport"`
+	AircraftID       *uint      `json:"aircraft_id"`
+	AircraftType     string     `json:"aircraft_type"`
+	AircraftTypeKey  string     `json:"aircraft_type_key"`
+	Seat1            string     `json:"seat1"`
+	Seat2            string     `json:"seat2"`
+	Seat3            string     `json:"seat3"`
+	CreatedAt        time.Time  `json:"created_at"`
+	DeletedAt        *time.Time `json:"deleted_at"`
+	ArchivedAt       time.Time  `json:"archived_at" gorm:"autoCreateTime"`
+}
+
+// NewArchivedVoucher copies a voucher into its archived form.
+func NewArchivedVoucher(voucher Voucher) ArchivedVoucher {
+	archived := ArchivedVoucher{
+		ID:               voucher.ID,
+		CrewName:         voucher.CrewName,
+		CrewID:           voucher.CrewID,
+		CrewBase:         voucher.CrewBase,
+		FlightNumber:     voucher.FlightNumber,
+		FlightDate:       voucher.FlightDate,
+		DepartureAirport: voucher.DepartureAirport,
+		AircraftID:       voucher.AircraftID,
+		AircraftType:     voucher.AircraftType,
+		AircraftTypeKey:  voucher.AircraftTypeKey,
+		Seat1:            voucher.Seat1,
+		Seat2:            voucher.Seat2,
+		Seat3:            voucher.Seat3,
+		CreatedAt:        voucher.CreatedAt,
+	}
+	if voucher.DeletedAt.Valid {
+		deletedAt := voucher.DeletedAt.Time
+		archived.DeletedAt = &deletedAt
+	}
+	return archived
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrAuditLogImmutable is returned when something tries to change or remove
+// an audit log entry.
+var ErrAuditLogImmutable = errors.New("audit log entries are append-only")
+
+// AuditLog records one create, update or delete of an aircraft or voucher.
+// Before, After, Diff and the seat columns hold JSON documents.
+type AuditLog struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	Actor           string    `json:"actor" gorm:"index"`
+	Action          string    `json:"action" gorm:"index"`
+	Entity          string    `json:"entity" gorm:"index:idx_audit_logs_entity"`
+	EntityID        uint      `json:"entity_id" gorm:"index:idx_audit_logs_entity"`
+	RequestID       string    `json:"request_id" gorm:"index"`
+	RemoteAddr      string    `json:"remote_addr"`
+	Before          string    `json:"before"`
+	After           string    `json:"after"`
+	Diff            string    `json:"diff"`
+	SeatsConsidered string    `json:"seats_considered"`
+	SeatsChosen     string    `json:"seats_chosen"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+// BeforeUpdate hook — audit log entries can never be changed
+func (a *AuditLog) BeforeUpdate(tx *gorm.DB) error {
+	return ErrAuditLogImmutable
+}
+
+// BeforeDelete hook — audit log entries can never be removed
+func (a *AuditLog) BeforeDelete(tx *gorm.DB) error {
+	return ErrAuditLogImmutable
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the wire and storage format of a Date.
+const DateLayout = "2006-01-02"
+
+// timestampLayouts are the formats flight dates were stored in while they
+// were timestamps, as written by the SQLite driver or sent by clients.
+// Values without a UTC offset are taken as UTC, as the driver does.
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+// Date is a civil calendar date without a time of day or time zone, such as
+// the local departure date of a flight. It is stored as "YYYY-MM-DD" text so
+// equality and range queries behave the same whatever offset clients use.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the calendar date of t in t's own location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a "YYYY-MM-DD" string.
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// ParseTimestamp parses a flight date stored as a timestamp before flight
+// dates became civil dates.
+func ParseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// AddDays returns the date n days later, or earlier for negative n.
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, n))
+}
+
+// Before reports whether d is earlier than other.
+func (d Date) Before(other Date) bool {
+	return d.String() < other.String()
+}
+
+// In returns the start of the date in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value *string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil || *value == "" {
+		*d = Date{}
+		return nil
+	}
+
+	parsed, err := ParseDate(*value)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", *value)
+	}
+	*d = parsed
+	return nil
+}
+
+// GormDataType stores dates in a date column.
+func (Date) GormDataType() string {
+	return "date"
+}
+
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
+
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Date{}
+		return nil
+	case time.Time:
+		return d.scanTime(v)
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Date", value)
+	}
+}
+
+// scanString accepts "YYYY-MM-DD" as well as the longer timestamps written
+// before flight dates became civil dates.
+func (d *Date) scanString(value string) error {
+	if len(value) > len(DateLayout) {
+		t, err := ParseTimestamp(value)
+		if err != nil {
+			return err
+		}
+		return d.scanTime(t)
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// scanTime takes midnight, which drivers return for date columns, as the
+// date it starts. Any other time is a timestamp written before flight dates
+// became civil dates; the row's departure airport is unknown here, so it is
+// converted to the default time zone. Migrate rewrites such rows using
+// their departure airport.
+func (d *Date) scanTime(t time.Time) error {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		*d = DateOf(t)
+		return nil
+	}
+	date, err := FlightDateAt(t, "")
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlightDateFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		airport string
+		want    string
+		wantErr bool
+	}{
+		{"plain date", "2025-12-01", "CGK", "2025-12-01", false},
+		{"plain date ignores the airport", "2025-12-01", "JFK", "2025-12-01", false},
+		{"UTC evening is the next day in Jakarta", "2025-11-30T20:00:00Z", "CGK", "2025-12-01", false},
+		{"non-local offset", "2025-12-01T01:00:00+09:00", "CGK", "2025-11-30", false},
+		{"non-local offset west of UTC", "2025-11-30T19:30:00-05:00", "DPS", "2025-12-01", false},
+		{"just before local midnight", "2025-11-30T16:59:59Z", "CGK", "2025-11-30", false},
+		{"at local midnight", "2025-11-30T17:00:00Z", "CGK", "2025-12-01", false},
+		{"just after local midnight", "2025-11-30T17:00:01+00:00", "CGK", "2025-12-01", false},
+		{"default time zone without airport", "2025-11-30T17:00:00Z", "", "2025-12-01", false},
+		{"half hour offset", "2025-11-30T18:29:00Z", "DEL", "2025-11-30", false},
+		{"half hour offset past midnight", "2025-11-30T18:30:00Z", "DEL", "2025-12-01", false},
+		// New York springs forward from EST (-05:00) to EDT (-04:00) at 02:00
+		// on 2025-03-09 and falls back at 02:00 on 2025-11-02.
+		{"spring forward, before midnight", "2025-03-09T04:59:59Z", "JFK", "2025-03-08", false},
+		{"spring forward, midnight", "2025-03-09T05:00:00Z", "JFK", "2025-03-09", false},
+		{"spring forward, skipped hour", "2025-03-09T07:30:00Z", "JFK", "2025-03-09", false},
+		{"spring forward, end of day in EDT", "2025-03-10T03:59:59Z", "JFK", "2025-03-09", false},
+		{"spring forward, next midnight in EDT", "2025-03-10T04:00:00Z", "JFK", "2025-03-10", false},
+		{"fall back, midnight in EDT", "2025-11-02T04:00:00Z", "JFK", "2025-11-02", false},
+		{"fall back, repeated hour", "2025-11-02T06:30:00Z", "JFK", "2025-11-02", false},
+		{"fall back, end of day in EST", "2025-11-03T04:59:59Z", "JFK", "2025-11-02", false},
+		{"fall back, next midnight in EST", "2025-11-03T05:00:00Z", "JFK", "2025-11-03", false},
+		// Sydney falls back from AEDT (+11:00) to AEST (+10:00) on 2025-04-06.
+		{"southern hemisphere fall back", "2025-04-05T13:30:00Z", "SYD", "2025-04-06", false},
+		{"southern hemisphere after fall back", "2025-04-06T13:30:00Z", "SYD", "2025-04-06", false},
+		{"unknown airport", "2025-11-30T17:00:00Z", "XXX", "", true},
+		{"malformed date", "2025-13-01", "CGK", "", true},
+		{"timestamp without offset", "2025-11-30T17:00:00", "CGK", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FlightDateFor(tt.value, tt.airport)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("FlightDateFor(%q, %q) = %s, want an error", tt.value, tt.airport, got)
+				}
+				return
+			}
+			if err != nil || got.String() != tt.want {
+				t.Errorf("FlightDateFor(%q, %q) = %s, %v, want %s", tt.value, tt.airport, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Date
+		wantErr bool
+	}{
+		{"2025-12-01", NewDate(2025, time.December, 1), false},
+		{"2024-02-29", NewDate(2024, time.February, 29), false},
+		{"2025-02-29", Date{}, true},
+		{"2025-12-1", Date{}, true},
+		{"2025-12-01T00:00:00Z", Date{}, true},
+		{"", Date{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDate(%q) = %v, %v, want %v (error %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDateScan(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    string
+		wantErr bool
+	}{
+		{"nil", nil, "", false},
+		{"date text", "2025-12-01", "2025-12-01", false},
+		{"date bytes", []byte("2025-12-01"), "2025-12-01", false},
+		{"driver date", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), "2025-12-01", false},
+		{"midnight with local offset", time.Date(2025, 12, 1, 0, 0, 0, 0, jakarta), "2025-12-01", false},
+		// Timestamps stored before flight dates became civil dates are read
+		// in the default time zone, Asia/Jakarta.
+		{"legacy UTC timestamp", "2025-11-30 17:00:00+00:00", "2025-12-01", false},
+		{"legacy timestamp before local midnight", "2025-11-30 16:59:59+00:00", "2025-11-30", false},
+		{"legacy RFC 3339 timestamp", "2025-11-30T17:00:00Z", "2025-12-01", false},
+		{"legacy timestamp with fraction", "2025-11-30 17:00:00.5+00:00", "2025-12-01", false},
+		{"legacy timestamp without offset", "2025-11-30 17:00:00", "2025-12-01", false},
+		{"legacy timestamp with local offset", "2025-12-01 00:30:00+07:00", "2025-12-01", false},
+		{"driver timestamp", time.Date(2025, 11, 30, 17, 0, 0, 0, time.UTC), "2025-12-01", false},
+		{"garbage", "yesterday", "", true},
+		{"number", 20251201, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Date
+			err := d.Scan(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Scan(%v) = %s, want an error", tt.value, d)
+				}
+				return
+			}
+			got := d.String()
+			if d.IsZero() {
+				got = ""
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("Scan(%v) = %s, %v, want %s", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateValueRoundTrip(t *testing.T) {
+	for _, d := range []Date{NewDate(2025, time.December, 1), NewDate(2024, time.February, 29), NewDate(1999, time.January, 9)} {
+		value, err := d.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != d.String() {
+			t.Errorf("Value() = %v, want %q", value, d.String())
+		}
+		var scanned Date
+		if err := scanned.Scan(value); err != nil || scanned != d {
+			t.Errorf("Scan(Value()) = %v, %v, want %v", scanned, err, d)
+		}
+	}
+
+	if value, err := (Date{}).Value(); value != nil || err != nil {
+		t.Errorf("zero Date Value() = %v, %v, want nil", value, err)
+	}
+}
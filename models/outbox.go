@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// OutboxEvent is an event waiting to be relayed to the event stream. It is
+// written in the transaction of the change it announces, so an event is
+// streamed if and only if its change was committed.
+type OutboxEvent struct {
+	ID          uint       `json:"id" gorm:"primaryKey"` // Relay order
+	EventID     string     `json:"event_id" gorm:"index"`
+	Type        string     `json:"type"`
+	Payload     string     `json:"payload"` // JSON encoded event
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	PublishedAt *time.Time `json:"published_at" gorm:"index"`
+	StreamID    string     `json:"stream_id"` // ID of the stream entry once relayed
+}
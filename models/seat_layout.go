@@ -0,0 +1,104 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AisleMarker separates the seat blocks of a row in Aircraft.SeatsPerRow,
+// e.g. "ABC-DEF" or "AB-CDEF-GH".
+const AisleMarker = '-'
+
+// Seat positions within a row.
+const (
+	SeatWindow = "window"
+	SeatMiddle = "middle"
+	SeatAisle  = "aisle"
+)
+
+// seatGroupSizes lists the seats between aisles for each row width, following
+// common cabin configurations, e.g. 3-3 for six seats or 3-4-3 for ten.
+var seatGroupSizes = map[int][]int{
+	1:  {1},
+	2:  {1, 1},
+	3:  {1, 2},
+	4:  {2, 2},
+	5:  {2, 3},
+	6:  {3, 3},
+	7:  {2, 3, 2},
+	8:  {2, 4, 2},
+	9:  {3, 3, 3},
+	10: {3, 4, 3},
+	11: {3, 5, 3},
+}
+
+// SeatLayoutFor adds aisle markers to seat letters written without them,
+// inferring the aisles from the number of seats per row, e.g. "ABCDEF"
+// becomes "ABC-DEF". Layouts that already have markers are returned as is.
+func SeatLayoutFor(letters string) string {
+	sizes, ok := seatGroupSizes[len(letters)]
+	if !ok || strings.ContainsRune(letters, AisleMarker) {
+		return letters
+	}
+
+	groups := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		groups = append(groups, letters[:size])
+		letters = letters[size:]
+	}
+	return strings.Join(groups, string(AisleMarker))
+}
+
+// SeatLetters returns the seat letters of a row without aisle markers, e.g.
+// "ABCDEF" for "ABC-DEF".
+func (a Aircraft) SeatLetters() string {
+	return strings.ReplaceAll(a.SeatsPerRow, string(AisleMarker), "")
+}
+
+// SeatGroups splits a row into the seat blocks between aisles, e.g. "ABC-DEF"
+// becomes ["ABC", "DEF"]. Layouts without aisle markers are split as
+// SeatLayoutFor would.
+func (a Aircraft) SeatGroups() []string {
+	return strings.Split(SeatLayoutFor(a.SeatsPerRow), string(AisleMarker))
+}
+
+// SeatPositions classifies every seat letter of a row as window, aisle or
+// middle. Seats that are both by a window and an aisle count as window seats.
+func (a Aircraft) SeatPositions() map[rune]string {
+	groups := a.SeatGroups()
+	positions := map[rune]string{}
+	for i, group := range groups {
+		for j, letter := range group {
+			switch {
+			case (i == 0 && j == 0) || (i == len(groups)-1 && j == len(group)-1):
+				positions[letter] = SeatWindow
+			case j == 0 || j == len(group)-1:
+				positions[letter] = SeatAisle
+			default:
+				positions[letter] = SeatMiddle
+			}
+		}
+	}
+	return positions
+}
+
+// SeatPosition returns the position of seat, e.g. "window" for "12A", or ""
+// when the seat letter is not on the aircraft.
+func (a Aircraft) SeatPosition(seat string) string {
+	if seat == "" {
+		return ""
+	}
+	return a.SeatPositions()[rune(seat[len(seat)-1])]
+}
+
+// HasSeat reports whether seat, e.g. "12C", exists on the aircraft.
+func (a Aircraft) HasSeat(seat string) bool {
+	if len(seat) < 2 || seat[0] < '1' || seat[0] > '9' {
+		return false
+	}
+	row, err := strconv.Atoi(seat[:len(seat)-1])
+	if err != nil || row > a.NumRows {
+		return false
+	}
+	return strings.ContainsRune(a.SeatLetters(), rune(seat[len(seat)-1]))
+}
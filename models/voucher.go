@@ -1,17 +1,37 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Voucher struct {
-	ID              uint      `json:"id" gorm:"primaryKey"`
-	CrewName        string    `json:"crew_name"`
-	CrewID          string    `json:"crew_id"`
-	FlightNumber    string    `json:"flight_number"`
-	FlightDate      time.Time `json:"flight_date"`
-	AircraftType    string    `json:"aircraft_type"`
-	AircraftTypeKey string    `json:"aircraft_type_key"`
-	Seat1           string    `json:"seat1"`
-	Seat2           string    `json:"seat2"`
-	Seat3           string    `json:"seat3"`
-	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	CrewName         string         `json:"crew_name"`
+	CrewID           string         `json:"crew_id"`
+	CrewBase         string         `json:"crew_base"` // IATA code of the crew member's home base, may be empty
+	FlightNumber     string         `json:"flight_number"`
+	FlightDate       Date           `json:"flight_date"`       // Local date at the departure airport
+	DepartureAirport string         `json:"departure_airport"` // IATA code, empty means DefaultTimezone
+	AircraftID       *uint          `json:"aircraft_id" gorm:"index"`
+	Aircraft         *Aircraft      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	AircraftType     string         `json:"aircraft_type"`     // Display name at the time the voucher was issued
+	AircraftTypeKey  string         `json:"aircraft_type_key"` // Stable key of the aircraft, see Aircraft.AircraftTypeKey
+	Seat1            string         `json:"seat1"`
+	Seat2            string         `json:"seat2"`
+	Seat3            string         `json:"seat3"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	DeletedAt        gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// Seats returns the seats assigned by the voucher.
+func (v Voucher) Seats() []string {
+	var seats []string
+	for _, seat := range []string{v.Seat1, v.Seat2, v.Seat3} {
+		if seat != "" {
+			seats = append(seats, seat)
+		}
+	}
+	return seats
 }
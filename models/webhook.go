@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook delivery states
+const (
+	DeliveryPending    = "pending"
+	DeliverySucceeded  = "succeeded"
+	DeliveryDeadLetter = "dead_letter"
+)
+
+// WebhookSubscription asks for the events listed in Events to be POSTed to URL.
+type WebhookSubscription struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	URL         string         `json:"url" gorm:"not null"`
+	Events      string         `json:"events" gorm:"not null"` // Comma-separated event types, e.g. "voucher.issued,voucher.cancelled"
+	Secret      string         `json:"-" gorm:"not null"`      // Key of the HMAC signature sent with every delivery
+	Description string         `json:"description"`
+	Active      bool           `json:"active"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// EventList returns the subscribed event types, e.g. ["voucher.issued"].
+func (s WebhookSubscription) EventList() []string {
+	events := []string{}
+	for _, event := range strings.Split(s.Events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// WebhookDelivery is one event on its way to one subscription. Deliveries
+// that keep failing end up in the dead-letter state, where they stay until
+// retried by hand.
+type WebhookDelivery struct {
+	ID             uint                 `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint                 `json:"subscription_id" gorm:"index"`
+	Subscription   *WebhookSubscription `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	EventID        string               `json:"event_id" gorm:"index"`
+	Event          string               `json:"event" gorm:"index"`
+	Payload        string               `json:"payload"` // JSON body POSTed to the subscription
+	Status         string               `json:"status" gorm:"index:idx_webhook_deliveries_due"`
+	Attempts       int                  `json:"attempts"`
+	NextAttemptAt  time.Time            `json:"next_attempt_at" gorm:"index:idx_webhook_deliveries_due"`
+	LastStatusCode int                  `json:"last_status_code"`
+	LastError      string               `json:"last_error"`
+	DeliveredAt    *time.Time           `json:"delivered_at"`
+	AttemptLog     []WebhookAttempt     `json:"-" gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE"`
+	CreatedAt      time.Time            `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time            `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// WebhookAttempt records the outcome of one POST of a delivery.
+type WebhookAttempt struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	DeliveryID uint      `json:"delivery_id" gorm:"index"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code"` // Zero when no response arrived
+	Error      string    `json:"error"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
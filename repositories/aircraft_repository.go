@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"VSA_GOGIN_BE/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// AircraftRepository persists aircraft.
+type AircraftRepository interface {
+	// Create and Update return ErrDuplicate when another aircraft, including
+	// a soft-deleted one, has the same type or type key.
+	Create(ctx context.Context, aircraft *models.Aircraft) error
+	Update(ctx context.Context, aircraft *models.Aircraft) error
+	// Delete soft-deletes the aircraft; it can be brought back with Restore.
+	Delete(ctx context.Context, aircraft *models.Aircraft) error
+	// DeleteWithVouchers soft-deletes the aircraft and every voucher issued
+	// for it in a single transaction.
+	DeleteWithVouchers(ctx context.Context, aircraft *models.Aircraft) error
+	Restore(ctx context.Context, aircraft *models.Aircraft) error
+	FindByID(ctx context.Context, id uint) (*models.Aircraft, error)
+	// FindByIDWithDeleted also returns soft-deleted aircraft.
+	FindByIDWithDeleted(ctx context.Context, id uint) (*models.Aircraft, error)
+	FindByKey(ctx context.Context, key string) (*models.Aircraft, error)
+	List(ctx context.Context, includeDeleted bool) ([]models.Aircraft, error)
+}
+
+type gormAircraftRepository struct {
+	db *gorm.DB
+}
+
+func NewAircraftRepository(db *gorm.DB) AircraftRepository {
+	return &gormAircraftRepository{db: db}
+}
+
+func (r *gormAircraftRepository) Create(ctx context.Context, aircraft *models.Aircraft) error {
+	return translateError(conn(ctx, r.db).Create(aircraft).Error)
+}
+
+func (r *gormAircraftRepository) Update(ctx context.Context, aircraft *models.Aircraft) error {
+	return translateError(conn(ctx, r.db).Save(aircraft).Error)
+}
+
+func (r *gormAircraftRepository) Delete(ctx context.Context, aircraft *models.Aircraft) error {
+	return conn(ctx, r.db).Delete(aircraft).Error
+}
+
+func (r *gormAircraftRepository) DeleteWithVouchers(ctx context.Context, aircraft *models.Aircraft) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("aircraft_id = ?", aircraft.ID).Delete(&models.Voucher{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(aircraft).Error
+	})
+}
+
+func (r *gormAircraftRepository) Restore(ctx context.Context, aircraft *models.Aircraft) error {
+	if err := conn(ctx, r.db).Unscoped().Model(aircraft).Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+	aircraft.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+func (r *gormAircraftRepository) FindByID(ctx context.Context, id uint) (*models.Aircraft, error) {
+	var aircraft models.Aircraft
+	if err := conn(ctx, r.db).First(&aircraft, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &aircraft, nil
+}
+
+func (r *gormAircraftRepository) FindByIDWithDeleted(ctx context.Context, id uint) (*models.Aircraft, error) {
+	var aircraft models.Aircraft
+	if err := conn(ctx, r.db).Unscoped().First(&aircraft, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &aircraft, nil
+}
+
+func (r *gormAircraftRepository) FindByKey(ctx context.Context, key string) (*models.Aircraft, error) {
+	var aircraft models.Aircraft
+	if err := conn(ctx, r.db).Where("aircraft_type_key = ?", key).First(&aircraft).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &aircraft, nil
+}
+
+func (r *gormAircraftRepository) List(ctx context.Context, includeDeleted bool) ([]models.Aircraft, error) {
+	query := conn(ctx, r.db)
+	if includeDeleted {
+		query = query.Unscoped()
+	}
+
+	var aircraft []models.Aircraft
+	if err := query.Find(&aircraft).Error; err != nil {
+		return nil, err
+	}
+	return aircraft, nil
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+
+	"gorm.io/gorm"
+)
+
+type aircraftRepository struct {
+	store[models.Aircraft]
+	// vouchers is deleted from by DeleteWithVouchers. It may be nil.
+	vouchers *voucherRepository
+}
+
+// NewAircraftRepository returns an empty aircraft repository. Vouchers is
+// where DeleteWithVouchers deletes from and may be nil.
+func NewAircraftRepository(vouchers repositories.VoucherRepository) repositories.AircraftRepository {
+	r := &aircraftRepository{store: newStore[models.Aircraft]()}
+	r.vouchers, _ = vouchers.(*voucherRepository)
+	return r
+}
+
+func (r *aircraftRepository) Create(ctx context.Context, aircraft *models.Aircraft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conflicts(aircraft) {
+		return repositories.ErrDuplicate
+	}
+	r.nextID++
+	aircraft.ID = r.nextID
+	r.rows[aircraft.ID] = *aircraft
+	return nil
+}
+
+func (r *aircraftRepository) Update(ctx context.Context, aircraft *models.Aircraft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conflicts(aircraft) {
+		return repositories.ErrDuplicate
+	}
+	r.rows[aircraft.ID] = *aircraft
+	return nil
+}
+
+// conflicts reports whether another aircraft, soft-deleted or not, has the
+// type or type key of aircraft, as the unique indexes would.
+func (r *aircraftRepository) conflicts(aircraft *models.Aircraft) bool {
+	for id, other := range r.rows {
+		if id != aircraft.ID && (other.AircraftType == aircraft.AircraftType || other.AircraftTypeKey == aircraft.AircraftTypeKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *aircraftRepository) Delete(ctx context.Context, aircraft *models.Aircraft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	aircraft.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.rows[aircraft.ID] = *aircraft
+	return nil
+}
+
+func (r *aircraftRepository) DeleteWithVouchers(ctx context.Context, aircraft *models.Aircraft) error {
+	if r.vouchers != nil {
+		vouchers, err := r.vouchers.FindByAircraftID(ctx, aircraft.ID)
+		if err != nil {
+			return err
+		}
+		for i := range vouchers {
+			if err := r.vouchers.Delete(ctx, &vouchers[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return r.Delete(ctx, aircraft)
+}
+
+func (r *aircraftRepository) Restore(ctx context.Context, aircraft *models.Aircraft) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	aircraft.DeletedAt = gorm.DeletedAt{}
+	r.rows[aircraft.ID] = *aircraft
+	return nil
+}
+
+func (r *aircraftRepository) FindByID(ctx context.Context, id uint) (*models.Aircraft, error) {
+	aircraft, err := r.FindByIDWithDeleted(ctx, id)
+	if err != nil || aircraft.DeletedAt.Valid {
+		return nil, repositories.ErrNotFound
+	}
+	return aircraft, nil
+}
+
+func (r *aircraftRepository) FindByIDWithDeleted(ctx context.Context, id uint) (*models.Aircraft, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	aircraft, ok := r.rows[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	return &aircraft, nil
+}
+
+func (r *aircraftRepository) FindByKey(ctx context.Context, key string) (*models.Aircraft, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range r.ids() {
+		if aircraft := r.rows[id]; aircraft.AircraftTypeKey == key && !aircraft.DeletedAt.Valid {
+			return &aircraft, nil
+		}
+	}
+	return nil, repositories.ErrNotFound
+}
+
+func (r *aircraftRepository) List(ctx context.Context, includeDeleted bool) ([]models.Aircraft, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	aircraft := []models.Aircraft{}
+	for _, id := range r.ids() {
+		if a := r.rows[id]; includeDeleted || !a.DeletedAt.Valid {
+			aircraft = append(aircraft, a)
+		}
+	}
+	return aircraft, nil
+}
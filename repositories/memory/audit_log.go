@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+)
+
+type auditLogRepository struct {
+	store[models.AuditLog]
+}
+
+// NewAuditLogRepository returns an empty audit log. List honours the entity
+// filters and the limit only.
+func NewAuditLogRepository() repositories.AuditLogRepository {
+	return &auditLogRepository{store: newStore[models.AuditLog]()}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, entry *models.AuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	entry.ID = r.nextID
+	entry.CreatedAt = time.Now()
+	r.rows[entry.ID] = *entry
+	return nil
+}
+
+func (r *auditLogRepository) List(ctx context.Context, filter repositories.AuditLogFilter) ([]models.AuditLog, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := []models.AuditLog{}
+	for _, id := range r.ids() {
+		entry := r.rows[id]
+		if (filter.Entity != "" && entry.Entity != filter.Entity) ||
+			(filter.EntityID != 0 && entry.EntityID != filter.EntityID) ||
+			(filter.Action != "" && entry.Action != filter.Action) {
+			continue
+		}
+		entries = append(entries, entry)
+		if filter.Limit > 0 && len(entries) == filter.Limit {
+			break
+		}
+	}
+	return entries, nil
+}
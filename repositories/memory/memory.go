@@ -0,0 +1,47 @@
+// Package memory provides in-memory implementations of the repositories, so
+// services and handlers can be tested without a database. They keep the
+// uniqueness rules of the database schema but none of its other behavior.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"VSA_GOGIN_BE/repositories"
+)
+
+// Transactor runs work directly. The fakes cannot roll back, so a failing fn
+// leaves the writes it made before failing in place.
+type Transactor struct{}
+
+func NewTransactor() Transactor {
+	return Transactor{}
+}
+
+func (Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+var _ repositories.Transactor = Transactor{}
+
+// store holds rows of one table, keyed by ID.
+type store[T any] struct {
+	mu     sync.Mutex
+	rows   map[uint]T
+	nextID uint
+}
+
+func newStore[T any]() store[T] {
+	return store[T]{rows: map[uint]T{}}
+}
+
+// ids returns the IDs in ascending order, so listings are stable.
+func (s *store[T]) ids() []uint {
+	ids := make([]uint, 0, len(s.rows))
+	for id := uint(1); id <= s.nextID; id++ {
+		if _, ok := s.rows[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
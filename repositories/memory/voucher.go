@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+
+	"gorm.io/gorm"
+)
+
+type voucherRepository struct {
+	store[models.Voucher]
+}
+
+// NewVoucherRepository returns an empty voucher repository. Archived
+// vouchers are dropped rather than kept in an archive.
+func NewVoucherRepository() repositories.VoucherRepository {
+	return &voucherRepository{store: newStore[models.Voucher]()}
+}
+
+func (r *voucherRepository) Create(ctx context.Context, voucher *models.Voucher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conflicts(voucher) {
+		return repositories.ErrDuplicate
+	}
+	r.nextID++
+	voucher.ID = r.nextID
+	voucher.CreatedAt = time.Now()
+	r.rows[voucher.ID] = *voucher
+	return nil
+}
+
+// conflicts reports whether the crew member holds another active voucher for
+// the flight, as the unique index would.
+func (r *voucherRepository) conflicts(voucher *models.Voucher) bool {
+	for id, other := range r.rows {
+		if id != voucher.ID && !other.DeletedAt.Valid && sameCrewOnFlight(other, voucher.CrewID, voucher.FlightNumber, voucher.FlightDate) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *voucherRepository) Delete(ctx context.Context, voucher *models.Voucher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	voucher.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.rows[voucher.ID] = *voucher
+	return nil
+}
+
+func (r *voucherRepository) Restore(ctx context.Context, voucher *models.Voucher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conflicts(voucher) {
+		return repositories.ErrDuplicate
+	}
+	voucher.DeletedAt = gorm.DeletedAt{}
+	r.rows[voucher.ID] = *voucher
+	return nil
+}
+
+func (r *voucherRepository) UpdateSeats(ctx context.Context, voucher *models.Voucher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.rows[voucher.ID]
+	if !ok {
+		return nil
+	}
+	stored.Seat1, stored.Seat2, stored.Seat3 = voucher.Seat1, voucher.Seat2, voucher.Seat3
+	r.rows[voucher.ID] = stored
+	return nil
+}
+
+func (r *voucherRepository) FindByID(ctx context.Context, id uint) (*models.Voucher, error) {
+	voucher, err := r.FindByIDWithDeleted(ctx, id)
+	if err != nil || voucher.DeletedAt.Valid {
+		return nil, repositories.ErrNotFound
+	}
+	return voucher, nil
+}
+
+func (r *voucherRepository) FindByIDWithDeleted(ctx context.Context, id uint) (*models.Voucher, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	voucher, ok := r.rows[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	return &voucher, nil
+}
+
+func (r *voucherRepository) List(ctx context.Context, includeDeleted bool) ([]models.Voucher, error) {
+	return r.find(func(v models.Voucher) bool { return includeDeleted || !v.DeletedAt.Valid }), nil
+}
+
+func (r *voucherRepository) FindByCrewOnFlight(ctx context.Context, crewID, flightNumber string, flightDate models.Date) (*models.Voucher, error) {
+	return r.first(func(v models.Voucher) bool { return sameCrewOnFlight(v, crewID, flightNumber, flightDate) })
+}
+
+func (r *voucherRepository) FindExistingMany(ctx context.Context, lookups []models.Voucher) ([]models.Voucher, error) {
+	return r.active(func(v models.Voucher) bool {
+		for _, l := range lookups {
+			if sameCrewOnFlight(v, l.CrewID, l.FlightNumber, l.FlightDate) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *voucherRepository) FindByFlight(ctx context.Context, flightNumber string, flightDate models.Date) ([]models.Voucher, error) {
+	return r.active(func(v models.Voucher) bool {
+		return v.FlightNumber == flightNumber && v.FlightDate == flightDate
+	}), nil
+}
+
+func (r *voucherRepository) FindByAircraftID(ctx context.Context, aircraftID uint) ([]models.Voucher, error) {
+	return r.active(func(v models.Voucher) bool { return v.AircraftID != nil && *v.AircraftID == aircraftID }), nil
+}
+
+func (r *voucherRepository) CountByAircraftID(ctx context.Context, aircraftID uint) (int64, error) {
+	vouchers, _ := r.FindByAircraftID(ctx, aircraftID)
+	return int64(len(vouchers)), nil
+}
+
+func (r *voucherRepository) ArchiveFlownBefore(ctx context.Context, cutoff models.Date, batchSize int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var archived int64
+	for id, v := range r.rows {
+		if v.FlightDate.Before(cutoff) {
+			delete(r.rows, id)
+			archived++
+		}
+	}
+	return archived, nil
+}
+
+func (r *voucherRepository) CountSeatsByFlight(ctx context.Context, from models.Date) ([]repositories.FlightSeatCount, error) {
+	type flight struct {
+		number     string
+		date       models.Date
+		aircraftID uint
+	}
+	var counts []repositories.FlightSeatCount
+	index := map[flight]int{}
+	for _, v := range r.active(func(v models.Voucher) bool { return v.AircraftID != nil && !v.FlightDate.Before(from) }) {
+		key := flight{v.FlightNumber, v.FlightDate, *v.AircraftID}
+		i, ok := index[key]
+		if !ok {
+			aircraft := models.Aircraft{ID: *v.AircraftID}
+			if v.Aircraft != nil {
+				aircraft = *v.Aircraft
+			}
+			i = len(counts)
+			index[key] = i
+			counts = append(counts, repositories.FlightSeatCount{FlightNumber: v.FlightNumber, FlightDate: v.FlightDate, Aircraft: aircraft})
+		}
+		counts[i].SeatsAssigned += len(v.Seats())
+	}
+	return counts, nil
+}
+
+// first returns the first active voucher matching match, or ErrNotFound.
+func (r *voucherRepository) first(match func(models.Voucher) bool) (*models.Voucher, error) {
+	vouchers := r.active(match)
+	if len(vouchers) == 0 {
+		return nil, repositories.ErrNotFound
+	}
+	return &vouchers[0], nil
+}
+
+// active returns the vouchers that are not soft-deleted and match match.
+func (r *voucherRepository) active(match func(models.Voucher) bool) []models.Voucher {
+	return r.find(func(v models.Voucher) bool { return !v.DeletedAt.Valid && match(v) })
+}
+
+func (r *voucherRepository) find(match func(models.Voucher) bool) []models.Voucher {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	vouchers := []models.Voucher{}
+	for _, id := range r.ids() {
+		if v := r.rows[id]; match(v) {
+			vouchers = append(vouchers, v)
+		}
+	}
+	return vouchers
+}
+
+func sameCrewOnFlight(v models.Voucher, crewID, flightNumber string, flightDate models.Date) bool {
+	return v.CrewID == crewID && v.FlightNumber == flightNumber && v.FlightDate == flightDate
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepository stores events until they are relayed to the event stream.
+type OutboxRepository interface {
+	Create(ctx context.Context, event *models.OutboxEvent) error
+	// FindUnpublished returns up to limit events not relayed yet, oldest
+	// first.
+	FindUnpublished(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+	// MarkPublished records that the event was relayed as the stream entry
+	// streamID.
+	MarkPublished(ctx context.Context, id uint, streamID string, publishedAt time.Time) error
+	CountUnpublished(ctx context.Context) (int64, error)
+	// DeletePublishedBefore removes events relayed before cutoff and returns
+	// how many were removed.
+	DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type gormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &gormOutboxRepository{db: db}
+}
+
+func (r *gormOutboxRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	return conn(ctx, r.db).Create(event).Error
+}
+
+func (r *gormOutboxRepository) FindUnpublished(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	if err := conn(ctx, r.db).
+		Where("published_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *gormOutboxRepository) MarkPublished(ctx context.Context, id uint, streamID string, publishedAt time.Time) error {
+	return conn(ctx, r.db).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"published_at": publishedAt, "stream_id": streamID}).Error
+}
+
+func (r *gormOutboxRepository) CountUnpublished(ctx context.Context) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&models.OutboxEvent{}).Where("published_at IS NULL").Count(&count).Error
+	return count, err
+}
+
+func (r *gormOutboxRepository) DeletePublishedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("published_at < ?", cutoff).Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
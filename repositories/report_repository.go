@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+
+	"VSA_GOGIN_BE/models"
+
+	"gorm.io/gorm"
+)
+
+// seatsAssignedSQL counts the seats a voucher row assigns.
+const seatsAssignedSQL = `CASE WHEN vouchers.seat1 <> '' THEN 1 ELSE 0 END +
+	CASE WHEN vouchers.seat2 <> '' THEN 1 ELSE 0 END +
+	CASE WHEN vouchers.seat3 <> '' THEN 1 ELSE 0 END`
+
+// capacitySQL mirrors models.Aircraft.AssignableSeatCount: the seat letters
+// of a row without aisle markers times the rows, less the blocked seats.
+// Rows written before blocked_seats existed may hold NULL there.
+const capacitySQL = `aircrafts.num_rows * LENGTH(REPLACE(aircrafts.seats_per_row, '-', '')) -
+	CASE WHEN COALESCE(aircrafts.blocked_seats, '') = '' THEN 0
+	ELSE LENGTH(COALESCE(aircrafts.blocked_seats, '')) - LENGTH(REPLACE(COALESCE(aircrafts.blocked_seats, ''), ',', '')) + 1 END`
+
+// ReportFilter narrows down report queries to active vouchers. Zero values
+// are ignored; From and To are inclusive flight dates.
+type ReportFilter struct {
+	From            models.Date
+	To              models.Date
+	AircraftTypeKey string
+	CrewBase        string
+	Limit           int
+	Offset          int
+}
+
+// FlightReportRow sums up the vouchers issued for one flight.
+type FlightReportRow struct {
+	FlightNumber  string
+	FlightDate    models.Date
+	Aircraft      models.Aircraft `gorm:"embedded"`
+	Vouchers      int
+	SeatsAssigned int
+}
+
+// AircraftTypeReportRow sums up the flights of one aircraft type.
+type AircraftTypeReportRow struct {
+	AircraftTypeKey string
+	AircraftType    string
+	Flights         int
+	Vouchers        int
+	SeatsAssigned   int
+	Capacity        int
+}
+
+// CrewBaseReportRow sums up the vouchers issued to crew of one home base.
+type CrewBaseReportRow struct {
+	CrewBase      string
+	Vouchers      int
+	CrewMembers   int
+	Flights       int
+	SeatsAssigned int
+}
+
+// CrewReportRow sums up the vouchers issued to one crew member.
+type CrewReportRow struct {
+	CrewID        string
+	CrewName      string
+	CrewBase      string
+	Vouchers      int
+	Flights       int
+	SeatsAssigned int
+	FirstFlight   models.Date
+	LastFlight    models.Date
+}
+
+// ReportRepository aggregates vouchers for utilization reports. The
+// aggregation runs in the database, backed by the flight date and crew base
+// indexes on vouchers.
+type ReportRepository interface {
+	// Flights groups vouchers by flight and date, ordered by date.
+	Flights(ctx context.Context, filter ReportFilter) ([]FlightReportRow, error)
+	// AircraftTypes groups the flights by aircraft type key, ordered by key.
+	AircraftTypes(ctx context.Context, filter ReportFilter) ([]AircraftTypeReportRow, error)
+	// CrewBases groups vouchers by crew base, busiest first.
+	CrewBases(ctx context.Context, filter ReportFilter) ([]CrewBaseReportRow, error)
+	// Crew groups vouchers by crew member, busiest first.
+	Crew(ctx context.Context, filter ReportFilter) ([]CrewReportRow, error)
+}
+
+type gormReportRepository struct {
+	db *gorm.DB
+}
+
+func NewReportRepository(db *gorm.DB) ReportRepository {
+	return &gormReportRepository{db: db}
+}
+
+func (r *gormReportRepository) Flights(ctx context.Context, filter ReportFilter) ([]FlightReportRow, error) {
+	var rows []FlightReportRow
+	if err := r.query(ctx, filter).
+		Select(`vouchers.flight_number, vouchers.flight_date,
+			aircrafts.id, aircrafts.aircraft_type, aircrafts.aircraft_type_key, aircrafts.num_rows, aircrafts.seats_per_row, aircrafts.blocked_seats,
+			COUNT(*) AS vouchers, SUM(` + seatsAssignedSQL + `) AS seats_assigned`).
+		Joins("JOIN aircrafts ON aircrafts.id = vouchers.aircraft_id").
+		Group("vouchers.flight_number, vouchers.flight_date, aircrafts.id").
+		Order("vouchers.flight_date, vouchers.flight_number").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *gormReportRepository) AircraftTypes(ctx context.Context, filter ReportFilter) ([]AircraftTypeReportRow, error) {
+	// The limit and offset page the aircraft types, not the flights.
+	unpaged := filter
+	unpaged.Limit, unpaged.Offset = 0, 0
+	flights := r.query(ctx, unpaged).
+		Select("vouchers.aircraft_id, COUNT(*) AS vouchers, SUM(" + seatsAssignedSQL + ") AS seats_assigned").
+		Group("vouchers.flight_number, vouchers.flight_date, vouchers.aircraft_id")
+
+	var rows []AircraftTypeReportRow
+	if err := paginate(conn(ctx, r.db).Table("(?) AS flights", flights), filter).
+		Select(`aircrafts.aircraft_type_key, MAX(aircrafts.aircraft_type) AS aircraft_type,
+			COUNT(*) AS flights, SUM(flights.vouchers) AS vouchers, SUM(flights.seats_assigned) AS seats_assigned,
+			SUM(` + capacitySQL + `) AS capacity`).
+		Joins("JOIN aircrafts ON aircrafts.id = flights.aircraft_id").
+		Group("aircrafts.aircraft_type_key").
+		Order("aircrafts.aircraft_type_key").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *gormReportRepository) CrewBases(ctx context.Context, filter ReportFilter) ([]CrewBaseReportRow, error) {
+	var rows []CrewBaseReportRow
+	if err := r.query(ctx, filter).
+		Select(`vouchers.crew_base, COUNT(*) AS vouchers, COUNT(DISTINCT vouchers.crew_id) AS crew_members,
+			COUNT(DISTINCT vouchers.flight_number || ' ' || vouchers.flight_date) AS flights,
+			SUM(` + seatsAssignedSQL + `) AS seats_assigned`).
+		Group("vouchers.crew_base").
+		Order("COUNT(*) DESC, vouchers.crew_base").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *gormReportRepository) Crew(ctx context.Context, filter ReportFilter) ([]CrewReportRow, error) {
+	var rows []CrewReportRow
+	if err := r.query(ctx, filter).
+		Select(`vouchers.crew_id, MAX(vouchers.crew_name) AS crew_name, MAX(vouchers.crew_base) AS crew_base,
+			COUNT(*) AS vouchers, COUNT(DISTINCT vouchers.flight_number || ' ' || vouchers.flight_date) AS flights,
+			SUM(` + seatsAssignedSQL + `) AS seats_assigned,
+			MIN(vouchers.flight_date) AS first_flight, MAX(vouchers.flight_date) AS last_flight`).
+		Group("vouchers.crew_id").
+		Order("COUNT(*) DESC, vouchers.crew_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// query selects the active vouchers matching filter.
+func (r *gormReportRepository) query(ctx context.Context, filter ReportFilter) *gorm.DB {
+	query := conn(ctx, r.db).Model(&models.Voucher{})
+	if !filter.From.IsZero() {
+		query = query.Where("vouchers.flight_date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("vouchers.flight_date < ?", filter.To.AddDays(1))
+	}
+	if filter.AircraftTypeKey != "" {
+		query = query.Where("vouchers.aircraft_type_key = ?", filter.AircraftTypeKey)
+	}
+	if filter.CrewBase != "" {
+		query = query.Where("vouchers.crew_base = ?", filter.CrewBase)
+	}
+	return paginate(query, filter)
+}
+
+// paginate applies the filter's limit and offset to query.
+func paginate(query *gorm.DB, filter ReportFilter) *gorm.DB {
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	return query
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+)
+
+func TestReportAircraftTypesGroupsFlightsInSQL(t *testing.T) {
+	db := openTestDB(t)
+	if err := db.AutoMigrate(&models.Voucher{}); err != nil {
+		t.Fatal(err)
+	}
+	atr := models.Aircraft{AircraftType: "ATR 72", AircraftTypeKey: "atr_72", NumRows: 2, SeatsPerRow: "AB-CD", BlockedSeats: "1A,1B"}
+	a320 := models.Aircraft{AircraftType: "Airbus A320", AircraftTypeKey: "a320", NumRows: 3, SeatsPerRow: "ABC-DEF"}
+	q400 := models.Aircraft{AircraftType: "Q400", AircraftTypeKey: "q400", NumRows: 2, SeatsPerRow: "AB-CD"}
+	for _, aircraft := range []*models.Aircraft{&atr, &a320, &q400} {
+		if err := db.Create(aircraft).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Aircraft created before blocked_seats existed hold NULL there.
+	if err := db.Exec("UPDATE aircrafts SET blocked_seats = NULL WHERE id = ?", q400.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	voucher := func(aircraft models.Aircraft, flight string, day int, seats ...string) *models.Voucher {
+		seats = append(seats, "", "", "")
+		return &models.Voucher{
+			CrewID:          flight + seats[0],
+			FlightNumber:    flight,
+			FlightDate:      models.NewDate(2025, time.January, day),
+			AircraftID:      &aircraft.ID,
+			AircraftTypeKey: aircraft.AircraftTypeKey,
+			Seat1:           seats[0],
+			Seat2:           seats[1],
+			Seat3:           seats[2],
+		}
+	}
+	deleted := voucher(a320, "GA3", 1, "2A")
+	for _, v := range []*models.Voucher{
+		voucher(atr, "GA1", 1, "1C", "1D", "2A"),
+		voucher(atr, "GA1", 1, "2B"),
+		voucher(atr, "GA2", 2, "2C", "2D"),
+		voucher(a320, "GA3", 1, "1A", "1B", "1C"),
+		voucher(q400, "GA4", 3, "1A"),
+		deleted,
+	} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Delete(deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	reports := NewReportRepository(db)
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		filter ReportFilter
+		want   []AircraftTypeReportRow
+	}{
+		{
+			name: "all",
+			want: []AircraftTypeReportRow{
+				{AircraftTypeKey: "a320", AircraftType: "Airbus A320", Flights: 1, Vouchers: 1, SeatsAssigned: 3, Capacity: a320.AssignableSeatCount()},
+				{AircraftTypeKey: "atr_72", AircraftType: "ATR 72", Flights: 2, Vouchers: 3, SeatsAssigned: 6, Capacity: 2 * atr.AssignableSeatCount()},
+				{AircraftTypeKey: "q400", AircraftType: "Q400", Flights: 1, Vouchers: 1, SeatsAssigned: 1, Capacity: q400.AssignableSeatCount()},
+			},
+		},
+		{
+			name:   "paged by aircraft type",
+			filter: ReportFilter{Limit: 1, Offset: 1},
+			want: []AircraftTypeReportRow{
+				{AircraftTypeKey: "atr_72", AircraftType: "ATR 72", Flights: 2, Vouchers: 3, SeatsAssigned: 6, Capacity: 2 * atr.AssignableSeatCount()},
+			},
+		},
+		{
+			name:   "filtered by date",
+			filter: ReportFilter{From: models.NewDate(2025, time.January, 2)},
+			want: []AircraftTypeReportRow{
+				{AircraftTypeKey: "atr_72", AircraftType: "ATR 72", Flights: 1, Vouchers: 1, SeatsAssigned: 2, Capacity: atr.AssignableSeatCount()},
+				{AircraftTypeKey: "q400", AircraftType: "Q400", Flights: 1, Vouchers: 1, SeatsAssigned: 1, Capacity: q400.AssignableSeatCount()},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reports.AircraftTypes(ctx, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AircraftTypes = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
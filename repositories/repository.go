@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the requested record does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// ErrDuplicate is returned when a write violates a unique constraint.
+var ErrDuplicate = errors.New("duplicate record")
+
+// translateError maps GORM and driver errors onto the repository's own error
+// values so callers do not depend on GORM or the database in use.
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	if isUniqueViolation(err) {
+		return errors.Join(ErrDuplicate, err)
+	}
+	return err
+}
+
+// isUniqueViolation recognizes SQLite unique constraint failures and the
+// Postgres unique_violation state.
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+
+	var stateErr sqlStateError
+	return errors.As(err, &stateErr) && stateErr.SQLState() == "23505"
+}
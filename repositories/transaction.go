@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+)
+
+// Transactor runs work inside a serializable database transaction.
+// Repository calls made with the context passed to fn join the transaction.
+type Transactor interface {
+	// WithinTransaction commits when fn returns nil and rolls back
+	// otherwise. Conflicts with concurrent transactions are retried, so fn
+	// may run more than once and must not have effects outside the database.
+	// Calls nested inside another transaction join it.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// RetryPolicy bounds how often a conflicting transaction is retried.
+type RetryPolicy struct {
+	MaxAttempts int
+	// BaseDelay doubles after every attempt, with jitter, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// DefaultRetryPolicy retries conflicts a few times within about a second.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 5, BaseDelay: 10 * time.Millisecond, MaxDelay: 500 * time.Millisecond}
+
+// serializable makes transactions behave as if they ran one after another,
+// so a check-then-write such as picking free seats cannot interleave with a
+// competing one. Databases that cannot guarantee it fail one of the
+// transactions with a serialization error, which is retried. SQLite takes
+// its write lock up front instead (see database.Open) and ignores the level.
+var serializable = &sql.TxOptions{Isolation: sql.LevelSerializable}
+
+type txKey struct{}
+
+type gormTransactor struct {
+	db     *gorm.DB
+	policy RetryPolicy
+}
+
+func NewTransactor(db *gorm.DB, policy RetryPolicy) Transactor {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &gormTransactor{db: db, policy: policy}
+}
+
+func (t *gormTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	delay := t.policy.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, txKey{}, tx))
+		}, serializable)
+		if err == nil || attempt >= t.policy.MaxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		// Jitter keeps conflicting callers from retrying in lockstep.
+		wait := delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		delay = min(delay*2, t.policy.MaxDelay)
+	}
+}
+
+// conn returns the transaction carried by ctx, or db outside a transaction.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// sqlStateError is implemented by Postgres driver errors.
+type sqlStateError interface {
+	SQLState() string
+}
+
+// IsRetryable reports whether err is a transaction conflict that succeeds
+// when retried: SQLite busy or locked errors, and Postgres serialization
+// failures and deadlocks.
+func IsRetryable(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		switch stateErr.SQLState() {
+		case "40001", "40P01":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(t.TempDir()+"/test.db?_txlock=immediate"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&models.Aircraft{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func countAircraft(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var count int64
+	if err := db.Model(&models.Aircraft{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestWithinTransactionCommitsAndRollsBack(t *testing.T) {
+	db := openTestDB(t)
+	tx := NewTransactor(db, DefaultRetryPolicy)
+	aircraft := NewAircraftRepository(db)
+	ctx := context.Background()
+	errFail := errors.New("fail")
+
+	err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := aircraft.Create(ctx, &models.Aircraft{AircraftType: "ATR 72", AircraftTypeKey: "atr_72"}); err != nil {
+			return err
+		}
+		// Nested calls join the outer transaction and roll back with it.
+		return tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := aircraft.Create(ctx, &models.Aircraft{AircraftType: "Q400", AircraftTypeKey: "q400"}); err != nil {
+				return err
+			}
+			return errFail
+		})
+	})
+	if !errors.Is(err, errFail) {
+		t.Fatalf("WithinTransaction = %v, want %v", err, errFail)
+	}
+	if n := countAircraft(t, db); n != 0 {
+		t.Errorf("%d aircraft after rollback, want 0", n)
+	}
+
+	if err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return aircraft.Create(ctx, &models.Aircraft{AircraftType: "ATR 72", AircraftTypeKey: "atr_72"})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countAircraft(t, db); n != 1 {
+		t.Errorf("%d aircraft after commit, want 1", n)
+	}
+}
+
+func TestWithinTransactionRetriesConflicts(t *testing.T) {
+	db := openTestDB(t)
+	tx := NewTransactor(db, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	busy := sqlite3.Error{Code: sqlite3.ErrBusy}
+
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"conflict then success", 2, busy, 3, false},
+		{"conflicts past the limit", 5, busy, 3, true},
+		{"other errors are not retried", 5, errors.New("boom"), 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := tx.WithinTransaction(context.Background(), func(ctx context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+			if calls != tt.wantCalls || (err != nil) != tt.wantErr {
+				t.Errorf("ran %d times with error %v, want %d times with error %v", calls, err, tt.wantCalls, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"VSA_GOGIN_BE/models"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// VoucherRepository persists vouchers.
+type VoucherRepository interface {
+	// Create returns ErrDuplicate when the crew member already holds a voucher
+	// for the flight.
+	Create(ctx context.Context, voucher *models.Voucher) error
+	// Delete soft-deletes the voucher; it can be brought back with Restore.
+	Delete(ctx context.Context, voucher *models.Voucher) error
+	// Restore returns ErrDuplicate when the crew member was issued another
+	// voucher for the flight in the meantime.
+	Restore(ctx context.Context, voucher *models.Voucher) error
+	// UpdateSeats writes the voucher's seats, leaving its other columns alone.
+	UpdateSeats(ctx context.Context, voucher *models.Voucher) error
+	FindByID(ctx context.Context, id uint) (*models.Voucher, error)
+	// FindByIDWithDeleted also returns soft-deleted vouchers.
+	FindByIDWithDeleted(ctx context.Context, id uint) (*models.Voucher, error)
+	List(ctx context.Context, includeDeleted bool) ([]models.Voucher, error)
+	// FindByCrewOnFlight returns the voucher of the crew member on the
+	// flight, whatever aircraft it was issued for, or ErrNotFound.
+	FindByCrewOnFlight(ctx context.Context, crewID, flightNumber string, flightDate models.Date) (*models.Voucher, error)
+	// FindExistingMany looks up the vouchers for several crew members and
+	// flights in one query, matching like FindByCrewOnFlight. Lookups
+	// without a voucher are simply absent.
+	FindExistingMany(ctx context.Context, lookups []models.Voucher) ([]models.Voucher, error)
+	// FindByFlight returns every voucher issued for a flight on a date.
+	FindByFlight(ctx context.Context, flightNumber string, flightDate models.Date) ([]models.Voucher, error)
+	FindByAircraftID(ctx context.Context, aircraftID uint) ([]models.Voucher, error)
+	CountByAircraftID(ctx context.Context, aircraftID uint) (int64, error)
+	// ArchiveFlownBefore moves vouchers, including soft-deleted ones, whose
+	// flight date is before cutoff into the archived_vouchers table and
+	// returns how many were moved.
+	ArchiveFlownBefore(ctx context.Context, cutoff models.Date, batchSize int) (int64, error)
+	// CountSeatsByFlight returns how many seats are assigned on each flight
+	// departing on or after from, per aircraft.
+	CountSeatsByFlight(ctx context.Context, from models.Date) ([]FlightSeatCount, error)
+}
+
+// FlightSeatCount is the number of seats assigned by vouchers on one flight.
+type FlightSeatCount struct {
+	FlightNumber  string
+	FlightDate    models.Date
+	Aircraft      models.Aircraft `gorm:"embedded"`
+	SeatsAssigned int
+}
+
+type gormVoucherRepository struct {
+	db *gorm.DB
+}
+
+func NewVoucherRepository(db *gorm.DB) VoucherRepository {
+	return &gormVoucherRepository{db: db}
+}
+
+func (r *gormVoucherRepository) Create(ctx context.Context, voucher *models.Voucher) error {
+	return translateError(conn(ctx, r.db).Create(voucher).Error)
+}
+
+func (r *gormVoucherRepository) Delete(ctx context.Context, voucher *models.Voucher) error {
+	return conn(ctx, r.db).Delete(voucher).Error
+}
+
+func (r *gormVoucherRepository) Restore(ctx context.Context, voucher *models.Voucher) error {
+	// Update by ID rather than through voucher, so a preloaded aircraft is not
+	// written back along with it.
+	if err := conn(ctx, r.db).Unscoped().Model(&models.Voucher{}).Where("id = ?", voucher.ID).Update("deleted_at", nil).Error; err != nil {
+		return translateError(err)
+	}
+	voucher.DeletedAt = gorm.DeletedAt{}
+	return nil
+}
+
+func (r *gormVoucherRepository) UpdateSeats(ctx context.Context, voucher *models.Voucher) error {
+	return conn(ctx, r.db).Model(&models.Voucher{}).
+		Where("id = ?", voucher.ID).
+		Select("seat1", "seat2", "seat3").
+		Updates(voucher).Error
+}
+
+func (r *gormVoucherRepository) FindByID(ctx context.Context, id uint) (*models.Voucher, error) {
+	var voucher models.Voucher
+	if err := conn(ctx, r.db).First(&voucher, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &voucher, nil
+}
+
+func (r *gormVoucherRepository) FindByIDWithDeleted(ctx context.Context, id uint) (*models.Voucher, error) {
+	var voucher models.Voucher
+	if err := conn(ctx, r.db).Unscoped().Scopes(withAircraft).First(&voucher, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &voucher, nil
+}
+
+func (r *gormVoucherRepository) List(ctx context.Context, includeDeleted bool) ([]models.Voucher, error) {
+	query := conn(ctx, r.db).Scopes(withAircraft)
+	if includeDeleted {
+		query = query.Unscoped()
+	}
+
+	var vouchers []models.Voucher
+	if err := query.Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *gormVoucherRepository) FindByCrewOnFlight(ctx context.Context, crewID, flightNumber string, flightDate models.Date) (*models.Voucher, error) {
+	var voucher models.Voucher
+	if err := conn(ctx, r.db).
+		Scopes(withAircraft).
+		Where("flight_number = ?", flightNumber).
+		Scopes(onDate(flightDate)).
+		Where("crew_id = ?", crewID).
+		First(&voucher).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &voucher, nil
+}
+
+func (r *gormVoucherRepository) FindExistingMany(ctx context.Context, lookups []models.Voucher) ([]models.Voucher, error) {
+	if len(lookups) == 0 {
+		return nil, nil
+	}
+
+	conditions := r.db
+	for _, l := range lookups {
+		conditions = conditions.Or(
+			"flight_number = ? AND flight_date >= ? AND flight_date < ? AND crew_id = ?",
+			l.FlightNumber, l.FlightDate, l.FlightDate.AddDays(1), l.CrewID,
+		)
+	}
+
+	var vouchers []models.Voucher
+	if err := conn(ctx, r.db).Scopes(withAircraft).Where(conditions).Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *gormVoucherRepository) FindByFlight(ctx context.Context, flightNumber string, flightDate models.Date) ([]models.Voucher, error) {
+	var vouchers []models.Voucher
+	if err := conn(ctx, r.db).
+		Where("flight_number = ?", flightNumber).
+		Scopes(onDate(flightDate)).
+		Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *gormVoucherRepository) FindByAircraftID(ctx context.Context, aircraftID uint) ([]models.Voucher, error) {
+	var vouchers []models.Voucher
+	if err := conn(ctx, r.db).Where("aircraft_id = ?", aircraftID).Find(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (r *gormVoucherRepository) CountByAircraftID(ctx context.Context, aircraftID uint) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&models.Voucher{}).Where("aircraft_id = ?", aircraftID).Count(&count).Error
+	return count, err
+}
+
+func (r *gormVoucherRepository) ArchiveFlownBefore(ctx context.Context, cutoff models.Date, batchSize int) (int64, error) {
+	var archived int64
+	for {
+		var vouchers []models.Voucher
+		err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Unscoped().
+				Where("flight_date < ?", cutoff).
+				Order("id").
+				Limit(batchSize).
+				Find(&vouchers).Error; err != nil {
+				return err
+			}
+			if len(vouchers) == 0 {
+				return nil
+			}
+
+			ids := make([]uint, 0, len(vouchers))
+			rows := make([]models.ArchivedVoucher, 0, len(vouchers))
+			for _, v := range vouchers {
+				ids = append(ids, v.ID)
+				rows = append(rows, models.NewArchivedVoucher(v))
+			}
+
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Delete(&models.Voucher{}, ids).Error
+		})
+		if err != nil {
+			return archived, err
+		}
+
+		archived += int64(len(vouchers))
+		if len(vouchers) < batchSize {
+			return archived, nil
+		}
+	}
+}
+
+func (r *gormVoucherRepository) CountSeatsByFlight(ctx context.Context, from models.Date) ([]FlightSeatCount, error) {
+	var counts []FlightSeatCount
+	if err := conn(ctx, r.db).Model(&models.Voucher{}).
+		Select(`vouchers.flight_number, vouchers.flight_date,
+			aircrafts.id, aircrafts.aircraft_type, aircrafts.aircraft_type_key, aircrafts.num_rows, aircrafts.seats_per_row, aircrafts.blocked_seats,
+			SUM(`+seatsAssignedSQL+`) AS seats_assigned`).
+		Joins("JOIN aircrafts ON aircrafts.id = vouchers.aircraft_id").
+		Where("vouchers.flight_date >= ?", from).
+		Group("vouchers.flight_number, vouchers.flight_date, aircrafts.id").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// withAircraft loads the aircraft of each voucher, including soft-deleted
+// aircraft, so responses can describe the seats of any voucher.
+func withAircraft(db *gorm.DB) *gorm.DB {
+	return db.Preload("Aircraft", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	})
+}
+
+// onDate matches flights departing on date. It queries the half-open range
+// [date, date+1) rather than equality so rows written with a time of day
+// before flight dates became civil dates still match.
+func onDate(date models.Date) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("flight_date >= ? AND flight_date < ?", date, date.AddDays(1))
+	}
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryFilter narrows down delivery log queries. Zero values are
+// ignored.
+type WebhookDeliveryFilter struct {
+	SubscriptionID uint
+	Event          string
+	Status         string
+	Limit          int
+	Offset         int
+}
+
+// WebhookRepository persists webhook subscriptions and their deliveries.
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	// DeleteSubscription soft-deletes the subscription; its pending
+	// deliveries are dead-lettered when they come up.
+	DeleteSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	FindSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error)
+	// SubscriptionsFor returns the active subscriptions to event.
+	SubscriptionsFor(ctx context.Context, event string) ([]models.WebhookSubscription, error)
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	// ClaimDue returns up to limit pending deliveries due at now, with their
+	// subscription loaded, and pushes their next attempt lease into the
+	// future. A worker that dies mid-delivery thus leaves them to be picked
+	// up again once the lease runs out.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+	// SaveAttempt stores the delivery's new state together with the attempt
+	// that led to it.
+	SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+	// UpdateDelivery stores the delivery's state, leaving its event alone.
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	// FindDelivery returns the delivery with its attempt log.
+	FindDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error)
+	// ListDeliveries returns the deliveries matching filter, newest first.
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]models.WebhookDelivery, error)
+}
+
+// deliveryStateColumns are the delivery columns that change while it is
+// being delivered.
+var deliveryStateColumns = []string{"status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "updated_at"}
+
+type gormWebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &gormWebhookRepository{db: db}
+}
+
+func (r *gormWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return translateError(conn(ctx, r.db).Create(subscription).Error)
+}
+
+func (r *gormWebhookRepository) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return translateError(conn(ctx, r.db).Save(subscription).Error)
+}
+
+func (r *gormWebhookRepository) DeleteSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return conn(ctx, r.db).Delete(subscription).Error
+}
+
+func (r *gormWebhookRepository) FindSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := conn(ctx, r.db).First(&subscription, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &subscription, nil
+}
+
+func (r *gormWebhookRepository) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := conn(ctx, r.db).Order("id").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *gormWebhookRepository) SubscriptionsFor(ctx context.Context, event string) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := conn(ctx, r.db).
+		Where("active = ?", true).
+		Where("',' || events || ',' LIKE ?", "%,"+event+",%").
+		Order("id").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *gormWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Create(&deliveries).Error
+}
+
+func (r *gormWebhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Preload("Subscription").
+			Where("status = ? AND next_attempt_at <= ?", models.DeliveryPending, now).
+			Order("next_attempt_at, id").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(deliveries))
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *gormWebhookRepository) SaveAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+		return updateDelivery(tx, delivery)
+	})
+}
+
+func (r *gormWebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return updateDelivery(conn(ctx, r.db), delivery)
+}
+
+// updateDelivery writes the state columns by ID, so neither the event nor a
+// preloaded subscription is written back.
+func updateDelivery(db *gorm.DB, delivery *models.WebhookDelivery) error {
+	return db.Model(&models.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Select(deliveryStateColumns).
+		Updates(delivery).Error
+}
+
+func (r *gormWebhookRepository) FindDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := conn(ctx, r.db).
+		Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		First(&delivery, id).Error; err != nil {
+		return nil, translateError(err)
+	}
+	return &delivery, nil
+}
+
+func (r *gormWebhookRepository) ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	query := conn(ctx, r.db).Order("id DESC")
+	if filter.SubscriptionID != 0 {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+	if filter.Event != "" {
+		query = query.Where("event = ?", filter.Event)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -6,12 +6,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupVoucherRoutes(router *gin.Engine, controller *controllers.VoucherController) {
+func SetupVoucherRoutes(router *gin.Engine, controller *controllers.VoucherController, rateLimit, idempotency gin.HandlerFunc) {
 	vouchers := router.Group("/api/vouchers")
 	{
 		vouchers.GET("/", controller.ListVouchers)
-		vouchers.POST("/generate", controller.GenerateVoucherSeat)
+		// Replays are answered before rate limiting, so retrying a request
+		// that went through does not use up the client's budget.
+		vouchers.POST("/generate", idempotency, rateLimit, controller.GenerateVoucherSeat)
 		vouchers.POST("/check", controller.CheckVoucherSeat)
+		vouchers.POST("/check/batch", controller.CheckVoucherSeats)
+		vouchers.DELETE("/:id", controller.DeleteVoucher)
+		vouchers.POST("/:id/restore", controller.RestoreVoucher)
+		vouchers.POST("/:id/regenerate", controller.RegenerateVoucherSeats)
 	}
 }
 
@@ -23,5 +29,61 @@ func SetupAircraftRoutes(router *gin.Engine, controller *controllers.AircraftCon
 		aircraft.GET("/:id", controller.GetAircraft)
 		aircraft.PUT("/:id", controller.UpdateAircraft)
 		aircraft.DELETE("/:id", controller.DeleteAircraft)
+		aircraft.POST("/:id/restore", controller.RestoreAircraft)
 	}
 }
+
+func SetupFlightRoutes(router *gin.Engine, controller *controllers.FlightController) {
+	flights := router.Group("/api/flights")
+	{
+		flights.GET("/:number/:date/seatmap", controller.GetSeatMap)
+	}
+}
+
+func SetupReportRoutes(router *gin.Engine, controller *controllers.ReportController) {
+	reports := router.Group("/api/reports")
+	{
+		reports.GET("/flights", controller.FlightReport)
+		reports.GET("/aircraft-types", controller.AircraftTypeReport)
+		reports.GET("/crew-bases", controller.CrewBaseReport)
+		reports.GET("/crew", controller.CrewReport)
+	}
+}
+
+func SetupWebhookRoutes(router *gin.Engine, controller *controllers.WebhookController) {
+	webhooks := router.Group("/api/webhooks")
+	{
+		webhooks.POST("/", controller.CreateSubscription)
+		webhooks.GET("/", controller.ListSubscriptions)
+		webhooks.GET("/deliveries", controller.ListDeliveries)
+		webhooks.GET("/deliveries/:id", controller.GetDelivery)
+		webhooks.POST("/deliveries/:id/retry", controller.RetryDelivery)
+		webhooks.GET("/:id", controller.GetSubscription)
+		webhooks.PUT("/:id", controller.UpdateSubscription)
+		webhooks.DELETE("/:id", controller.DeleteSubscription)
+	}
+}
+
+func SetupEventRoutes(router *gin.Engine, controller *controllers.EventController) {
+	events := router.Group("/api/events")
+	{
+		events.GET("/", controller.ReadEvents)
+		events.GET("/stream", controller.GetStreamInfo)
+		events.POST("/groups/:group/replay", controller.ReplayEvents)
+	}
+}
+
+func SetupAuditRoutes(router *gin.Engine, controller *controllers.AuditController) {
+	audit := router.Group("/api/audit")
+	{
+		audit.GET("/", controller.ListAuditLogs)
+		audit.GET("/export", controller.ExportAuditLogs)
+	}
+}
+
+// SetupHealthRoutes registers the probes outside /api, where container
+// orchestrators expect them.
+func SetupHealthRoutes(router *gin.Engine, controller *controllers.HealthController) {
+	router.GET("/healthz", controller.Healthz)
+	router.GET("/readyz", controller.Readyz)
+}
@@ -1,31 +1,36 @@
 package seed
 
 import (
-	"log"
+	"strings"
 
+	"VSA_GOGIN_BE/logging"
 	"VSA_GOGIN_BE/models"
 
 	"gorm.io/gorm"
 )
 
-func SeedAircrafts(db *gorm.DB) {
-	// Define the default aircrafts
-	defaultAircrafts := []models.Aircraft{
-		{AircraftTypeKey: "atr_72", AircraftType: "ATR 72", NumRows: 18, SeatsPerRow: "ABCDF"},
-		{AircraftTypeKey: "airbus_a320", AircraftType: "Airbus A320", NumRows: 32, SeatsPerRow: "ABCDEF"},
-		{AircraftTypeKey: "boeing_737", AircraftType: "Boeing 737", NumRows: 32, SeatsPerRow: "ABCDEF"},
-	}
+func SeedAircrafts(db *gorm.DB, fixtures *Fixtures) {
+	for _, fixture := range fixtures.Aircraft {
+		aircraft := models.Aircraft{
+			AircraftTypeKey: fixture.AircraftTypeKey,
+			AircraftType:    fixture.AircraftType,
+			NumRows:         fixture.NumRows,
+			SeatsPerRow:     models.SeatLayoutFor(fixture.SeatsPerRow),
+			BlockedSeats:    strings.Join(fixture.BlockedSeats, ","),
+		}
+		if aircraft.AircraftTypeKey == "" {
+			aircraft.AircraftTypeKey = models.AircraftTypeKeyFor(aircraft.AircraftType)
+		}
 
-	for _, aircraft := range defaultAircrafts {
 		var existing models.Aircraft
-		err := db.Where("aircraft_type = ?", aircraft.AircraftType).First(&existing).Error
+		err := db.Unscoped().Where("aircraft_type_key = ?", aircraft.AircraftTypeKey).First(&existing).Error
 
 		// Only create if not exists
 		if err == gorm.ErrRecordNotFound {
 			if err := db.Create(&aircraft).Error; err != nil {
-				log.Printf("Failed to seed aircraft %s: %v", aircraft.AircraftType, err)
+				logging.For("seed").Error("failed to seed aircraft", "aircraft_type", aircraft.AircraftType, "error", err)
 			} else {
-				log.Printf("Seeded aircraft: %s", aircraft.AircraftType)
+				logging.For("seed").Info("seeded aircraft", "aircraft_type", aircraft.AircraftType)
 			}
 		}
 	}
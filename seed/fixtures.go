@@ -0,0 +1,93 @@
+package seed
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+)
+
+// FixtureVersion is the fixture file format understood by LoadFixtures.
+const FixtureVersion = 1
+
+// ProfileNone seeds nothing and is meant for production.
+const ProfileNone = "prod-none"
+
+// Profiles lists the fixture profiles built into the binary.
+var Profiles = []string{"dev", "demo", "test", ProfileNone}
+
+//go:embed fixtures/*.json
+var embedded embed.FS
+
+// Fixtures is the content of a fixture file, fixtures/<profile>.json.
+type Fixtures struct {
+	Version  int               `json:"version"`
+	Aircraft []AircraftFixture `json:"aircraft"`
+	Vouchers []VoucherFixture  `json:"vouchers"`
+}
+
+// AircraftFixture is a default aircraft, matched to existing rows by type key.
+type AircraftFixture struct {
+	AircraftTypeKey string   `json:"aircraft_type_key"`
+	AircraftType    string   `json:"aircraft_type"`
+	NumRows         int      `json:"num_rows"`
+	SeatsPerRow     string   `json:"seats_per_row"`
+	BlockedSeats    []string `json:"blocked_seats,omitempty"`
+}
+
+// VoucherFixture is a sample voucher. The flight date is either a fixed
+// FlightDate or DaysFromToday, so demo data stays in the future.
+type VoucherFixture struct {
+	CrewName         string   `json:"crew_name"`
+	CrewID           string   `json:"crew_id"`
+	CrewBase         string   `json:"crew_base"`
+	FlightNumber     string   `json:"flight_number"`
+	FlightDate       string   `json:"flight_date"`
+	DaysFromToday    int      `json:"days_from_today"`
+	DepartureAirport string   `json:"departure_airport"`
+	AircraftTypeKey  string   `json:"aircraft_type_key"`
+	Seats            []string `json:"seats"`
+}
+
+// LoadFixtures reads the fixtures of profile from fsys, or from the fixtures
+// built into the binary when fsys is nil.
+func LoadFixtures(fsys fs.FS, profile string) (*Fixtures, error) {
+	name := profile + ".json"
+	if fsys == nil {
+		fsys, name = embedded, "fixtures/"+name
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("unknown seed profile %q", profile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("invalid fixtures for profile %s: %w", profile, err)
+	}
+	if fixtures.Version != FixtureVersion {
+		return nil, fmt.Errorf("fixtures for profile %s have version %d, expected %d", profile, fixtures.Version, FixtureVersion)
+	}
+	return &fixtures, nil
+}
+
+// flightDate resolves the fixture's flight date, counting DaysFromToday in
+// the departure airport's time zone.
+func (f VoucherFixture) flightDate() (models.Date, error) {
+	if f.FlightDate != "" {
+		return models.ParseDate(f.FlightDate)
+	}
+	loc, err := models.AirportLocation(f.DepartureAirport)
+	if err != nil {
+		return models.Date{}, err
+	}
+	return models.DateOf(time.Now().In(loc)).AddDays(f.DaysFromToday), nil
+}
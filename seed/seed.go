@@ -0,0 +1,54 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// ErrSeedPending is returned by Check until Run has finished.
+var ErrSeedPending = errors.New("seeding has not completed")
+
+// Dataset is a named section of the fixtures.
+type Dataset struct {
+	Name string
+	Seed func(db *gorm.DB, fixtures *Fixtures)
+}
+
+// Datasets lists the datasets in the order they are loaded, since vouchers
+// refer to the aircraft.
+var Datasets = []Dataset{
+	{Name: "aircraft", Seed: SeedAircrafts},
+	{Name: "vouchers", Seed: SeedVouchers},
+}
+
+var completed atomic.Bool
+
+// Run loads the named datasets of fixtures, or all of them when none are
+// named, and marks seeding complete. Rows that exist already are left alone.
+func Run(db *gorm.DB, fixtures *Fixtures, names ...string) error {
+	for _, name := range names {
+		if !slices.ContainsFunc(Datasets, func(d Dataset) bool { return d.Name == name }) {
+			return fmt.Errorf("unknown seed dataset %q", name)
+		}
+	}
+
+	for _, dataset := range Datasets {
+		if len(names) == 0 || slices.Contains(names, dataset.Name) {
+			dataset.Seed(db, fixtures)
+		}
+	}
+	completed.Store(true)
+	return nil
+}
+
+// Check reports ErrSeedPending until Run has finished.
+func Check() error {
+	if !completed.Load() {
+		return ErrSeedPending
+	}
+	return nil
+}
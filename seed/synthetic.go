@@ -0,0 +1,120 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"VSA_GOGIN_BE/models"
+
+	"gorm.io/gorm"
+)
+
+// maxSyntheticFlightNumber keeps synthetic flight numbers within the four
+// digits a flight number allows.
+const maxSyntheticFlightNumber = 9999
+
+// syntheticCrewBases are the home bases synthetic crew members are spread over.
+var syntheticCrewBases = []string{"CGK", "DPS", "SUB", "KNO", "UPG"}
+
+// SyntheticOptions sizes the data created by Synthetic.
+type SyntheticOptions struct {
+	// Flights are spread over Days consecutive days from StartDate.
+	Flights   int
+	Days      int
+	StartDate models.Date
+	// VouchersPerFlight is capped by the seats of the flight's aircraft.
+	VouchersPerFlight int
+	// RandSeed makes the seat assignment reproducible.
+	RandSeed int64
+}
+
+// SyntheticResult counts what Synthetic created.
+type SyntheticResult struct {
+	Flights  int
+	Vouchers int
+}
+
+// Synthetic creates flights with vouchers for load testing. Flights are
+// numbered ZZ1, ZZ2, ... and cycle through the existing aircraft; every
+// voucher gets up to three distinct free seats. Flights that already have
+// vouchers are skipped, so the generator can be rerun.
+func Synthetic(ctx context.Context, db *gorm.DB, opts SyntheticOptions) (SyntheticResult, error) {
+	var result SyntheticResult
+	if opts.Flights <= 0 || opts.VouchersPerFlight <= 0 || opts.Days <= 0 {
+		return result, errors.New("flights, vouchers per flight and days must be positive")
+	}
+	if (opts.Flights+opts.Days-1)/opts.Days > maxSyntheticFlightNumber {
+		return result, fmt.Errorf("at most %d flights per day can be generated", maxSyntheticFlightNumber)
+	}
+
+	db = db.WithContext(ctx)
+	var aircraft []models.Aircraft
+	if err := db.Order("id").Find(&aircraft).Error; err != nil {
+		return result, err
+	}
+	if len(aircraft) == 0 {
+		return result, errors.New("no aircraft to assign flights to; seed or import aircraft first")
+	}
+
+	r := rand.New(rand.NewSource(opts.RandSeed))
+	for i := 0; i < opts.Flights; i++ {
+		flightNumber := fmt.Sprintf("ZZ%d", i/opts.Days+1)
+		flightDate := opts.StartDate.AddDays(i % opts.Days)
+		plane := aircraft[i%len(aircraft)]
+
+		var existing int64
+		if err := db.Model(&models.Voucher{}).Where("flight_number = ? AND flight_date = ?", flightNumber, flightDate).Count(&existing).Error; err != nil {
+			return result, err
+		}
+		if existing > 0 {
+			continue
+		}
+
+		vouchers := syntheticVouchers(r, flightNumber, flightDate, plane, opts.VouchersPerFlight)
+		if err := db.CreateInBatches(vouchers, 100).Error; err != nil {
+			return result, fmt.Errorf("failed to create vouchers for %s on %s: %w", flightNumber, flightDate, err)
+		}
+		result.Flights++
+		result.Vouchers += len(vouchers)
+	}
+	return result, nil
+}
+
+func syntheticVouchers(r *rand.Rand, flightNumber string, flightDate models.Date, aircraft models.Aircraft, count int) []models.Voucher {
+	var seats []string
+	for row := 1; row <= aircraft.NumRows; row++ {
+		for _, letter := range aircraft.SeatLetters() {
+			if seat := fmt.Sprintf("%d%c", row, letter); !aircraft.IsBlocked(seat) {
+				seats = append(seats, seat)
+			}
+		}
+	}
+	r.Shuffle(len(seats), func(i, j int) { seats[i], seats[j] = seats[j], seats[i] })
+
+	vouchers := make([]models.Voucher, 0, count)
+	for n := 1; n <= count && len(seats) > 0; n++ {
+		taken := min(3, len(seats))
+		voucher := models.Voucher{
+			CrewName:        fmt.Sprintf("Synthetic Crew %d", n),
+			CrewID:          fmt.Sprintf("SYN-%s-%d", flightNumber, n),
+			CrewBase:        syntheticCrewBases[r.Intn(len(syntheticCrewBases))],
+			FlightNumber:    flightNumber,
+			FlightDate:      flightDate,
+			AircraftID:      &aircraft.ID,
+			AircraftType:    aircraft.AircraftType,
+			AircraftTypeKey: aircraft.AircraftTypeKey,
+			Seat1:           seats[0],
+		}
+		if taken > 1 {
+			voucher.Seat2 = seats[1]
+		}
+		if taken > 2 {
+			voucher.Seat3 = seats[2]
+		}
+		seats = seats[taken:]
+		vouchers = append(vouchers, voucher)
+	}
+	return vouchers
+}
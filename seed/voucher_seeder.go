@@ -1,53 +1,59 @@
 package seed
 
 import (
-	"log"
-	"time"
-
+	"VSA_GOGIN_BE/logging"
 	"VSA_GOGIN_BE/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func SeedVouchers(db *gorm.DB) {
-	// Define the default vouchers
-	defaultVouchers := []models.Voucher{
-		{
-			CrewName:        "Sinta",
-			CrewID:          "S001",
-			FlightNumber:    "ID001",
-			FlightDate:      time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
-			AircraftType:    "ATR 72",
-			AircraftTypeKey: "atr_72",
-			Seat1:           "1F",
-			Seat2:           "6A",
-			Seat3:           "2D",
-		},
-		{
-			CrewName:        "Dwi",
-			CrewID:          "D001",
-			FlightNumber:    "ID001",
-			FlightDate:      time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC),
-			AircraftType:    "Airbus A320",
-			AircraftTypeKey: "airbus_a320",
-			Seat1:           "8F",
-			Seat2:           "3C",
-			Seat3:           "17B",
-		},
-		// Add more vouchers as needed
-	}
+func SeedVouchers(db *gorm.DB, fixtures *Fixtures) {
+	for _, fixture := range fixtures.Vouchers {
+		flightDate, err := fixture.flightDate()
+		if err != nil {
+			logging.For("seed").Error("failed to seed voucher: invalid flight date", "crew_id", fixture.CrewID, "error", err)
+			continue
+		}
+		voucher := models.Voucher{
+			CrewName:         fixture.CrewName,
+			CrewID:           fixture.CrewID,
+			CrewBase:         fixture.CrewBase,
+			FlightNumber:     fixture.FlightNumber,
+			FlightDate:       flightDate,
+			DepartureAirport: fixture.DepartureAirport,
+			AircraftTypeKey:  fixture.AircraftTypeKey,
+		}
+		for i, seat := range fixture.Seats {
+			switch i {
+			case 0:
+				voucher.Seat1 = seat
+			case 1:
+				voucher.Seat2 = seat
+			case 2:
+				voucher.Seat3 = seat
+			}
+		}
 
-	for _, voucher := range defaultVouchers {
-		var existing models.Voucher
-		err := db.Where("flight_number = ? AND flight_date = ? AND aircraft_type_key = ? AND crew_id = ?", voucher.FlightNumber, voucher.FlightDate, voucher.AircraftTypeKey, voucher.CrewID).First(&existing).Error
+		var aircraft models.Aircraft
+		if err := db.Where("aircraft_type_key = ?", voucher.AircraftTypeKey).First(&aircraft).Error; err != nil {
+			logging.For("seed").Error("failed to seed voucher: aircraft not found", "aircraft_type_key", voucher.AircraftTypeKey)
+			continue
+		}
+		voucher.AircraftID = &aircraft.ID
+		voucher.AircraftType = aircraft.AircraftType
 
-		// Only create if not exists
-		if err == gorm.ErrRecordNotFound {
-			if err := db.Create(&voucher).Error; err != nil {
-				log.Printf("Failed to seed voucher: %v", err)
-			} else {
-				log.Printf("Seeded voucher: %s on %s with aircraft type %s", voucher.FlightNumber, voucher.FlightDate, voucher.AircraftType)
-			}
+		// Only create if not exists; the unique index on crew, flight and
+		// date turns a repeated voucher into a no-op
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&voucher)
+		if result.Error != nil {
+			logging.For("seed").Error("failed to seed voucher", "error", result.Error)
+		} else if result.RowsAffected > 0 {
+			logging.For("seed").Info("seeded voucher",
+				"flight_number", voucher.FlightNumber,
+				"flight_date", voucher.FlightDate.String(),
+				"aircraft_type", voucher.AircraftType,
+			)
 		}
 	}
 }
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"VSA_GOGIN_BE/models"
+)
+
+// AircraftEvent is the data of aircraft events.
+type AircraftEvent struct {
+	AircraftID      uint     `json:"aircraft_id"`
+	AircraftType    string   `json:"aircraft_type"`
+	AircraftTypeKey string   `json:"aircraft_type_key"`
+	NumRows         int      `json:"num_rows"`
+	SeatsPerRow     string   `json:"seats_per_row"`
+	BlockedSeats    []string `json:"blocked_seats"`
+}
+
+func newAircraftEvent(aircraft *models.Aircraft) AircraftEvent {
+	return AircraftEvent{
+		AircraftID:      aircraft.ID,
+		AircraftType:    aircraft.AircraftType,
+		AircraftTypeKey: aircraft.AircraftTypeKey,
+		NumRows:         aircraft.NumRows,
+		SeatsPerRow:     aircraft.SeatsPerRow,
+		BlockedSeats:    aircraft.BlockedSeatList(),
+	}
+}
+
+// publish announces a change to aircraft. It must run inside the
+// transaction making the change.
+func (s *AircraftService) publish(ctx context.Context, eventType string, data interface{}) error {
+	if s.Events == nil {
+		return nil
+	}
+	if err := s.Events.Publish(ctx, NewEvent(eventType, data)); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", eventType, err)
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/validators"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DeletePolicy decides what happens to vouchers when their aircraft is deleted.
+type DeletePolicy string
+
+const (
+	// DeletePolicyBlock refuses to delete aircraft that vouchers still reference.
+	DeletePolicyBlock DeletePolicy = "block"
+	// DeletePolicyCascade deletes the referencing vouchers together with the aircraft.
+	DeletePolicyCascade DeletePolicy = "cascade"
+)
+
+type AircraftService struct {
+	Aircraft     repositories.AircraftRepository
+	Vouchers     repositories.VoucherRepository
+	Audit        *AuditService
+	DeletePolicy DeletePolicy
+	Tx           repositories.Transactor
+	// RDB holds the voucher seat cache, which is dropped for an aircraft
+	// whose layout changes. It may be nil.
+	RDB *redis.Client
+	// Events is told about every aircraft change, and about the vouchers
+	// cancelled together with an aircraft. It may be nil.
+	Events EventPublisher
+}
+
+func NewAircraftService(aircraft repositories.AircraftRepository, vouchers repositories.VoucherRepository, audit *AuditService, rdb *redis.Client, deletePolicy DeletePolicy, tx repositories.Transactor, events EventPublisher) *AircraftService {
+	return &AircraftService{
+		Aircraft:     aircraft,
+		Vouchers:     vouchers,
+		Audit:        audit,
+		RDB:          rdb,
+		DeletePolicy: deletePolicy,
+		Tx:           tx,
+		Events:       events,
+	}
+}
+
+// List all aircraft, optionally including soft-deleted ones
+func (s *AircraftService) ListAircraft(ctx context.Context, includeDeleted bool) ([]models.Aircraft, error) {
+	return s.Aircraft.List(ctx, includeDeleted)
+}
+
+// Get aircraft by ID
+func (s *AircraftService) GetAircraft(ctx context.Context, id uint) (*models.Aircraft, error) {
+	aircraft, err := s.Aircraft.FindByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrAircraftNotFound
+	}
+	return aircraft, err
+}
+
+// Create aircraft, deriving its type key from the display name when not set.
+// The key is never changed afterwards so vouchers keep pointing at it. Seat
+// letters given without aisle markers get them inferred.
+func (s *AircraftService) CreateAircraft(ctx context.Context, aircraft *models.Aircraft) error {
+	if err := validateAircraft(aircraft); err != nil {
+		return err
+	}
+	aircraft.SeatsPerRow = models.SeatLayoutFor(aircraft.SeatsPerRow)
+
+	if aircraft.AircraftTypeKey == "" {
+		aircraft.AircraftTypeKey = models.AircraftTypeKeyFor(aircraft.AircraftType)
+	}
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.Aircraft.Create(ctx, aircraft); err != nil {
+			return aircraftWriteError(aircraft, err)
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionCreate,
+			Entity:   AuditEntityAircraft,
+			EntityID: aircraft.ID,
+			After:    aircraft,
+		}); err != nil {
+			return err
+		}
+		return s.publish(ctx, EventAircraftCreated, newAircraftEvent(aircraft))
+	})
+}
+
+// Update an existing aircraft loaded with GetAircraft. Renames keep the
+// original type key, and aisle markers are inferred as on create.
+func (s *AircraftService) UpdateAircraft(ctx context.Context, aircraft *models.Aircraft) error {
+	if err := validateAircraft(aircraft); err != nil {
+		return err
+	}
+	aircraft.SeatsPerRow = models.SeatLayoutFor(aircraft.SeatsPerRow)
+
+	err := s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.GetAircraft(ctx, aircraft.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.Aircraft.Update(ctx, aircraft); err != nil {
+			return aircraftWriteError(aircraft, err)
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionUpdate,
+			Entity:   AuditEntityAircraft,
+			EntityID: aircraft.ID,
+			Before:   before,
+			After:    aircraft,
+		}); err != nil {
+			return err
+		}
+		return s.publish(ctx, EventAircraftUpdated, newAircraftEvent(aircraft))
+	})
+	if err != nil {
+		return err
+	}
+
+	// Cached free seats may no longer exist or may be blocked now. Voucher
+	// generation drops those as well, so a failure here only costs a cache
+	// miss later.
+	if err := forgetAircraftSeats(ctx, s.RDB, aircraft.AircraftTypeKey); err != nil {
+		logging.For("services").WarnContext(ctx, "failed to drop cached seats of updated aircraft",
+			"aircraft_type_key", aircraft.AircraftTypeKey,
+			"error", err,
+		)
+	}
+	return nil
+}
+
+// Soft-delete aircraft by ID, applying the delete policy to referencing vouchers
+func (s *AircraftService) DeleteAircraft(ctx context.Context, id uint) error {
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		aircraft, err := s.GetAircraft(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if s.DeletePolicy == DeletePolicyCascade {
+			vouchers, err := s.Vouchers.FindByAircraftID(ctx, aircraft.ID)
+			if err != nil {
+				return err
+			}
+			if err := s.Aircraft.DeleteWithVouchers(ctx, aircraft); err != nil {
+				return err
+			}
+
+			for i := range vouchers {
+				if err := s.Audit.Record(ctx, AuditEntry{
+					Action:   AuditActionDelete,
+					Entity:   AuditEntityVoucher,
+					EntityID: vouchers[i].ID,
+					Before:   &vouchers[i],
+				}); err != nil {
+					return err
+				}
+				if err := s.publish(ctx, EventVoucherCancelled, newVoucherEvent(&vouchers[i])); err != nil {
+					return err
+				}
+			}
+		} else {
+			count, err := s.Vouchers.CountByAircraftID(ctx, aircraft.ID)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: %d voucher(s) reference it", ErrAircraftInUse, count)
+			}
+			if err := s.Aircraft.Delete(ctx, aircraft); err != nil {
+				return err
+			}
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionDelete,
+			Entity:   AuditEntityAircraft,
+			EntityID: aircraft.ID,
+			Before:   aircraft,
+		}); err != nil {
+			return err
+		}
+		return s.publish(ctx, EventAircraftDeleted, newAircraftEvent(aircraft))
+	})
+}
+
+// Restore a soft-deleted aircraft by ID
+func (s *AircraftService) RestoreAircraft(ctx context.Context, id uint) (*models.Aircraft, error) {
+	aircraft, err := s.Aircraft.FindByIDWithDeleted(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrAircraftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !aircraft.DeletedAt.Valid {
+		return aircraft, nil
+	}
+
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.Aircraft.Restore(ctx, aircraft); err != nil {
+			return err
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionRestore,
+			Entity:   AuditEntityAircraft,
+			EntityID: aircraft.ID,
+			After:    aircraft,
+		}); err != nil {
+			return err
+		}
+		return s.publish(ctx, EventAircraftRestored, newAircraftEvent(aircraft))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aircraft, nil
+}
+
+// aircraftWriteError reports unique constraint violations as
+// ErrAircraftExists. The conflicting aircraft may be a soft-deleted one, which
+// has to be restored rather than created again.
+func aircraftWriteError(aircraft *models.Aircraft, err error) error {
+	if errors.Is(err, repositories.ErrDuplicate) {
+		return fmt.Errorf("%w: %s or its type key %s is taken, possibly by a deleted aircraft", ErrAircraftExists, aircraft.AircraftType, aircraft.AircraftTypeKey)
+	}
+	return err
+}
+
+func validateAircraft(aircraft *models.Aircraft) error {
+	if aircraft.AircraftType == "" {
+		return fmt.Errorf("%w: aircraft_type must not be empty", ErrInvalidAircraft)
+	}
+	if aircraft.NumRows <= 0 {
+		return fmt.Errorf("%w: num_rows must be greater than 0", ErrInvalidAircraft)
+	}
+	if !validators.ValidSeatLetters(aircraft.SeatsPerRow) {
+		return fmt.Errorf("%w: seats_per_row must contain unique uppercase seat letters between A and K, with - marking aisles", ErrInvalidAircraft)
+	}
+	for _, seat := range aircraft.BlockedSeatList() {
+		if !aircraft.HasSeat(seat) {
+			return fmt.Errorf("%w: blocked seat %s is not on the aircraft", ErrInvalidAircraft, seat)
+		}
+	}
+	return nil
+}
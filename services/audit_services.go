@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"VSA_GOGIN_BE/audit"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+)
+
+// Audit actions
+const (
+	AuditActionCreate     = "create"
+	AuditActionUpdate     = "update"
+	AuditActionDelete     = "delete"
+	AuditActionRestore    = "restore"
+	AuditActionGenerate   = "generate"
+	AuditActionRegenerate = "regenerate"
+)
+
+// Audited entities
+const (
+	AuditEntityAircraft = "aircraft"
+	AuditEntityVoucher  = "voucher"
+	AuditEntityWebhook  = "webhook"
+)
+
+// AuditEntry describes a change to be written to the audit log.
+type AuditEntry struct {
+	Action          string
+	Entity          string
+	EntityID        uint
+	Before          interface{}
+	After           interface{}
+	SeatsConsidered []string
+	SeatsChosen     []string
+}
+
+type AuditService struct {
+	Logs repositories.AuditLogRepository
+}
+
+func NewAuditService(logs repositories.AuditLogRepository) *AuditService {
+	return &AuditService{Logs: logs}
+}
+
+// Record appends an entry to the audit log, attributing it to the actor and
+// request carried by ctx. Call it inside the transaction making the change:
+// the entry is committed or rolled back together with the change, so a
+// change is never committed without its entry. A failed write is returned
+// and must fail the transaction.
+func (s *AuditService) Record(ctx context.Context, entry AuditEntry) error {
+	metadata := audit.MetadataFrom(ctx)
+	record := models.AuditLog{
+		Actor:           metadata.Actor,
+		RequestID:       metadata.RequestID,
+		RemoteAddr:      metadata.RemoteAddr,
+		Action:          entry.Action,
+		Entity:          entry.Entity,
+		EntityID:        entry.EntityID,
+		Before:          toJSON(entry.Before),
+		After:           toJSON(entry.After),
+		Diff:            toJSON(audit.Diff(entry.Before, entry.After)),
+		SeatsConsidered: toJSON(entry.SeatsConsidered),
+		SeatsChosen:     toJSON(entry.SeatsChosen),
+	}
+
+	if err := s.Logs.Create(ctx, &record); err != nil {
+		return fmt.Errorf("failed to write audit log for %s %d: %w", entry.Entity, entry.EntityID, err)
+	}
+	return nil
+}
+
+// List audit log entries matching the filter
+func (s *AuditService) ListAuditLogs(ctx context.Context, filter repositories.AuditLogFilter) ([]models.AuditLog, error) {
+	return s.Logs.List(ctx, filter)
+}
+
+// toJSON encodes value for storage, using an empty string for nil values.
+func toJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
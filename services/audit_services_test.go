@@ -0,0 +1,59 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/repositories/memory"
+	"VSA_GOGIN_BE/services"
+)
+
+// brokenAuditLog fails every write.
+type brokenAuditLog struct {
+	repositories.AuditLogRepository
+}
+
+var errAuditDown = errors.New("audit log unavailable")
+
+func (brokenAuditLog) Create(context.Context, *models.AuditLog) error {
+	return errAuditDown
+}
+
+func TestAuditFailureFailsTheChange(t *testing.T) {
+	vouchers := memory.NewVoucherRepository()
+	audit := services.NewAuditService(brokenAuditLog{})
+	service := services.NewAircraftService(memory.NewAircraftRepository(vouchers), vouchers, audit, nil, services.DeletePolicyBlock, memory.NewTransactor(), nil)
+
+	aircraft := models.Aircraft{AircraftType: "ATR 72", NumRows: 18, SeatsPerRow: "AB-CD"}
+	if err := service.CreateAircraft(context.Background(), &aircraft); !errors.Is(err, errAuditDown) {
+		t.Errorf("CreateAircraft with a broken audit log = %v, want %v", err, errAuditDown)
+	}
+}
+
+func TestRecordAttributesEntries(t *testing.T) {
+	logs := memory.NewAuditLogRepository()
+	audit := services.NewAuditService(logs)
+	ctx := context.Background()
+
+	err := audit.Record(ctx, services.AuditEntry{
+		Action:   services.AuditActionUpdate,
+		Entity:   services.AuditEntityAircraft,
+		EntityID: 7,
+		Before:   map[string]int{"num_rows": 18},
+		After:    map[string]int{"num_rows": 20},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, _ := logs.List(ctx, repositories.AuditLogFilter{Entity: services.AuditEntityAircraft, EntityID: 7})
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+	if entries[0].Action != services.AuditActionUpdate || entries[0].Diff == "" {
+		t.Errorf("entry = %+v, want an update with a diff", entries[0])
+	}
+}
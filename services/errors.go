@@ -0,0 +1,23 @@
+package services
+
+import "errors"
+
+var (
+	ErrAircraftNotFound = errors.New("aircraft not found")
+	ErrAircraftInUse    = errors.New("aircraft is referenced by existing vouchers")
+	ErrAircraftExists   = errors.New("aircraft already exists")
+	ErrInvalidAircraft  = errors.New("invalid aircraft")
+	ErrVoucherNotFound  = errors.New("voucher not found")
+	ErrVoucherExists    = errors.New("voucher already generated")
+	ErrSeatsReassigned  = errors.New("voucher seats have been assigned to another voucher")
+	ErrNoSeatsAvailable = errors.New("no seats available for this flight")
+	ErrFlightNotFound   = errors.New("no vouchers issued for this flight")
+	ErrWebhookNotFound  = errors.New("webhook subscription not found")
+	ErrInvalidWebhook   = errors.New("invalid webhook subscription")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrDeliveryPending  = errors.New("webhook delivery is still pending")
+
+	ErrInvalidStreamOffset   = errors.New("invalid stream offset, expected an entry ID such as 1767225600000-0 or 0")
+	ErrConsumerGroupNotFound = errors.New("consumer group not found")
+	ErrRelayLockLost         = errors.New("outbox relay lock expired or taken over by another relay")
+)
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EventHandler handles one event stream entry. Entries whose handler fails
+// are handed out again later.
+type EventHandler func(ctx context.Context, event StreamEvent) error
+
+// EventConsumer reads the event stream as one consumer of a consumer group,
+// so the consumers of a group share its entries between them. An entry is
+// acknowledged only once its handler succeeded, which makes delivery at
+// least once: entries a failed handler or a crashed consumer left behind are
+// claimed again after MinIdle.
+type EventConsumer struct {
+	RDB      *redis.Client
+	Stream   string
+	Group    string
+	Consumer string
+	// MinIdle is how long an entry stays unacknowledged before any consumer
+	// of the group may claim it.
+	MinIdle time.Duration
+	// Block is how long a read waits for new entries.
+	Block time.Duration
+	Count int64
+}
+
+func NewEventConsumer(rdb *redis.Client, stream, group, consumer string) *EventConsumer {
+	return &EventConsumer{
+		RDB:      rdb,
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  time.Minute,
+		Block:    5 * time.Second,
+		Count:    100,
+	}
+}
+
+// Consume hands entries to handle until ctx is done. Stale entries of the
+// group are claimed before new ones are read.
+func (c *EventConsumer) Consume(ctx context.Context, handle EventHandler) error {
+	for ctx.Err() == nil {
+		claimed, _, err := c.RDB.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   c.Stream,
+			Group:    c.Group,
+			Consumer: c.Consumer,
+			MinIdle:  c.MinIdle,
+			Start:    "0",
+			Count:    c.Count,
+		}).Result()
+		if err != nil {
+			return c.readError(ctx, err)
+		}
+		c.handle(ctx, claimed, handle)
+
+		streams, err := c.RDB.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    c.Group,
+			Consumer: c.Consumer,
+			Streams:  []string{c.Stream, ">"},
+			Count:    c.Count,
+			Block:    c.Block,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return c.readError(ctx, err)
+		}
+		for _, stream := range streams {
+			c.handle(ctx, stream.Messages, handle)
+		}
+	}
+	return nil
+}
+
+// handle runs handle on each message, acknowledging the ones it succeeded on.
+func (c *EventConsumer) handle(ctx context.Context, messages []redis.XMessage, handle EventHandler) {
+	for _, event := range streamEvents(messages) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := handle(ctx, event); err != nil {
+			logging.For("events").WarnContext(ctx, "event handler failed; the entry will be claimed again",
+				"group", c.Group,
+				"consumer", c.Consumer,
+				"stream_id", event.StreamID,
+				"event_id", event.EventID,
+				"error", err,
+			)
+			continue
+		}
+		if err := c.RDB.XAck(ctx, c.Stream, c.Group, event.StreamID).Err(); err != nil {
+			logging.For("events").WarnContext(ctx, "failed to acknowledge event", "stream_id", event.StreamID, "error", err)
+		}
+	}
+}
+
+func (c *EventConsumer) readError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	if isRedisError(err, "NOGROUP") {
+		return fmt.Errorf("%w: %s", ErrConsumerGroupNotFound, c.Group)
+	}
+	return err
+}
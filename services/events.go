@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+)
+
+// Event types
+const (
+	EventVoucherIssued      = "voucher.issued"
+	EventVoucherRegenerated = "voucher.regenerated"
+	EventVoucherCancelled   = "voucher.cancelled"
+	EventVoucherRestored    = "voucher.restored"
+	EventAircraftCreated    = "aircraft.created"
+	EventAircraftUpdated    = "aircraft.updated"
+	EventAircraftDeleted    = "aircraft.deleted"
+	EventAircraftRestored   = "aircraft.restored"
+)
+
+// Event announces a change to downstream systems. It is the JSON body of
+// webhook deliveries and of the payload field of event stream entries.
+type Event struct {
+	// ID is the same for every copy of the event, whether redelivered to a
+	// webhook or replayed from the stream, so receivers can drop duplicates.
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// NewEvent returns an event of the given type with a fresh ID.
+func NewEvent(eventType string, data interface{}) Event {
+	return Event{
+		ID:        "evt_" + randomHex(16),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+}
+
+// EventPublisher announces changes to downstream systems. Publish is called
+// inside the transaction making the change, so a publisher writing through
+// the repositories commits or rolls back together with it.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// EventPublishers hands every event to each of its publishers in turn.
+type EventPublishers []EventPublisher
+
+func (p EventPublishers) Publish(ctx context.Context, event Event) error {
+	for _, publisher := range p {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// randomHex returns n random bytes, hex-encoded.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
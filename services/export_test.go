@@ -0,0 +1,7 @@
+package services
+
+// Exported for the tests in services_test.
+const (
+	RelayLockKey    = relayLockKey
+	OutboxBatchSize = outboxBatchSize
+)
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HealthCheck probes one dependency, returning an error when it is unusable.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthCheckResult is the outcome of a single HealthCheck.
+type HealthCheckResult struct {
+	Name    string
+	Latency time.Duration
+	Err     error
+}
+
+// HealthService runs the liveness and readiness checks behind the health
+// endpoints.
+type HealthService struct {
+	// Liveness checks fail only when restarting the process would help.
+	Liveness []HealthCheck
+	// Readiness checks fail while the service cannot handle requests.
+	Readiness []HealthCheck
+	// Timeout bounds every check.
+	Timeout time.Duration
+}
+
+func NewHealthService(liveness, readiness []HealthCheck, timeout time.Duration) *HealthService {
+	return &HealthService{
+		Liveness:  liveness,
+		Readiness: readiness,
+		Timeout:   timeout,
+	}
+}
+
+// Run the liveness checks
+func (s *HealthService) Live(ctx context.Context) ([]HealthCheckResult, bool) {
+	return s.run(ctx, s.Liveness)
+}
+
+// Run the readiness checks
+func (s *HealthService) Ready(ctx context.Context) ([]HealthCheckResult, bool) {
+	return s.run(ctx, s.Readiness)
+}
+
+// run executes checks concurrently, so one slow dependency does not delay
+// reporting on the others, and reports whether all of them passed.
+func (s *HealthService) run(ctx context.Context, checks []HealthCheck) ([]HealthCheckResult, bool) {
+	results := make([]HealthCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, s.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Check(checkCtx)
+			results[i] = HealthCheckResult{Name: check.Name, Latency: time.Since(start), Err: err}
+		}()
+	}
+	wg.Wait()
+
+	healthy := true
+	for _, result := range results {
+		if result.Err != nil {
+			healthy = false
+		}
+	}
+	return results, healthy
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/metrics"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// outboxBatchSize is how many events are relayed at a time.
+	outboxBatchSize = 100
+	// relayLockKey makes sure a single relay streams the outbox at a time,
+	// so entries appear in the stream in the order of the outbox. The lock
+	// expires after relayLockTTL unless the relay extends it, which it does
+	// after every batch.
+	relayLockKey = "outbox:relay_lock"
+	relayLockTTL = 30 * time.Second
+)
+
+// streamOffset matches the entry IDs of a Redis stream, e.g.
+// "1767225600000-0", and "0" for the start of the stream.
+var streamOffset = regexp.MustCompile(`^(0|[0-9]+-[0-9]+)$`)
+
+// unlockScript releases the relay lock only if this relay still holds it.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// extendLockScript pushes the relay lock's expiry out to ARGV[2]
+// milliseconds, but only if this relay still holds it.
+var extendLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// StreamEvent is one entry of the event stream.
+type StreamEvent struct {
+	// StreamID is the entry's offset in the stream.
+	StreamID string
+	EventID  string
+	Type     string
+	// Payload is the JSON encoded Event.
+	Payload string
+}
+
+// ConsumerGroup describes how far a consumer group has read the stream.
+type ConsumerGroup struct {
+	Name      string
+	Consumers int64
+	// Pending counts entries delivered to the group but not acknowledged.
+	Pending         int64
+	LastDeliveredID string
+	// Lag counts entries the group has yet to read, or -1 when Redis cannot
+	// tell, e.g. after entries were trimmed.
+	Lag int64
+}
+
+// EventStreamInfo describes the event stream and the outbox feeding it.
+type EventStreamInfo struct {
+	Stream string
+	Length int64
+	// Backlog counts outbox events not relayed yet.
+	Backlog int64
+	Groups  []ConsumerGroup
+}
+
+type OutboxService struct {
+	Outbox repositories.OutboxRepository
+	RDB    *redis.Client
+	// Stream is the key of the Redis stream events are relayed to, trimmed
+	// to about MaxLen entries. Zero keeps every entry.
+	Stream string
+	MaxLen int64
+}
+
+func NewOutboxService(outbox repositories.OutboxRepository, rdb *redis.Client, stream string, maxLen int64) *OutboxService {
+	return &OutboxService{
+		Outbox: outbox,
+		RDB:    rdb,
+		Stream: stream,
+		MaxLen: maxLen,
+	}
+}
+
+// Publish writes the event to the outbox. Called inside a transaction, the
+// event is committed or rolled back together with the change it announces.
+func (s *OutboxService) Publish(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.Outbox.Create(ctx, &models.OutboxEvent{
+		EventID: event.ID,
+		Type:    event.Type,
+		Payload: string(payload),
+	})
+}
+
+// Relay appends the outbox events not relayed yet to the stream in outbox
+// order, returning how many were relayed. An event whose entry was added
+// but not marked as relayed, e.g. because the process died in between, is
+// appended again later; consumers drop such duplicates by event ID. Relay
+// does nothing while another replica is relaying, and stops with
+// ErrRelayLockLost should its lock run out between two batches.
+func (s *OutboxService) Relay(ctx context.Context) (int, error) {
+	token := randomHex(16)
+	locked, err := s.RDB.SetNX(ctx, relayLockKey, token, relayLockTTL).Result()
+	if err != nil || !locked {
+		return 0, err
+	}
+	defer unlockScript.Run(context.WithoutCancel(ctx), s.RDB, []string{relayLockKey}, token)
+
+	relayed := 0
+	for {
+		events, err := s.Outbox.FindUnpublished(ctx, outboxBatchSize)
+		if err != nil {
+			return relayed, err
+		}
+		for _, event := range events {
+			streamID, err := s.RDB.XAdd(ctx, &redis.XAddArgs{
+				Stream: s.Stream,
+				MaxLen: s.MaxLen,
+				Approx: true,
+				Values: []interface{}{"event_id", event.EventID, "type", event.Type, "payload", event.Payload},
+			}).Result()
+			if err != nil {
+				return relayed, fmt.Errorf("failed to add event %s to stream: %w", event.EventID, err)
+			}
+			if err := s.Outbox.MarkPublished(ctx, event.ID, streamID, time.Now().UTC()); err != nil {
+				return relayed, err
+			}
+			metrics.EventsStreamed.WithLabelValues(event.Type).Inc()
+			relayed++
+		}
+		if len(events) < outboxBatchSize {
+			return relayed, nil
+		}
+
+		extended, err := extendLockScript.Run(ctx, s.RDB, []string{relayLockKey}, token, relayLockTTL.Milliseconds()).Int()
+		if err != nil {
+			return relayed, fmt.Errorf("failed to extend the relay lock: %w", err)
+		}
+		if extended == 0 {
+			return relayed, ErrRelayLockLost
+		}
+	}
+}
+
+// Remove outbox events relayed longer ago than retention, returning how
+// many were removed
+func (s *OutboxService) PruneOutbox(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.Outbox.DeletePublishedBefore(ctx, time.Now().UTC().Add(-retention))
+}
+
+// CreateGroups creates the consumer groups that do not exist yet. New
+// groups start reading at the beginning of the stream, so they see every
+// event still in it.
+func (s *OutboxService) CreateGroups(ctx context.Context, groups []string) error {
+	for _, group := range groups {
+		err := s.RDB.XGroupCreateMkStream(ctx, s.Stream, group, "0").Err()
+		if err != nil && !isRedisError(err, "BUSYGROUP") {
+			return fmt.Errorf("failed to create consumer group %s: %w", group, err)
+		}
+		if err == nil {
+			logging.For("events").InfoContext(ctx, "created consumer group", "stream", s.Stream, "group", group)
+		}
+	}
+	return nil
+}
+
+// Replay makes the consumer group read the stream again from the entry
+// after offset, or from the beginning when offset is "0". Entries the group
+// has been handed but not acknowledged stay pending.
+func (s *OutboxService) Replay(ctx context.Context, group, offset string) error {
+	if !streamOffset.MatchString(offset) {
+		return fmt.Errorf("%w: %s", ErrInvalidStreamOffset, offset)
+	}
+	err := s.RDB.XGroupSetID(ctx, s.Stream, group, offset).Err()
+	if isRedisError(err, "NOGROUP") {
+		return fmt.Errorf("%w: %s", ErrConsumerGroupNotFound, group)
+	}
+	if err != nil {
+		return err
+	}
+
+	logging.For("events").InfoContext(ctx, "replaying event stream", "stream", s.Stream, "group", group, "offset", offset)
+	return nil
+}
+
+// Read up to count stream entries following offset, or from the start of
+// the stream when offset is empty, without involving any consumer group
+func (s *OutboxService) ReadStream(ctx context.Context, offset string, count int64) ([]StreamEvent, error) {
+	start := "-"
+	if offset != "" {
+		if !streamOffset.MatchString(offset) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidStreamOffset, offset)
+		}
+		start = "(" + offset
+	}
+
+	messages, err := s.RDB.XRangeN(ctx, s.Stream, start, "+", count).Result()
+	if err != nil {
+		return nil, err
+	}
+	return streamEvents(messages), nil
+}
+
+// Describe the stream, its consumer groups and the outbox backlog
+func (s *OutboxService) StreamInfo(ctx context.Context) (*EventStreamInfo, error) {
+	info := EventStreamInfo{Stream: s.Stream, Groups: []ConsumerGroup{}}
+
+	backlog, err := s.Outbox.CountUnpublished(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info.Backlog = backlog
+
+	if info.Length, err = s.RDB.XLen(ctx, s.Stream).Result(); err != nil {
+		return nil, err
+	}
+	groups, err := s.RDB.XInfoGroups(ctx, s.Stream).Result()
+	if err != nil && !strings.Contains(err.Error(), "no such key") {
+		return nil, err
+	}
+	for _, g := range groups {
+		info.Groups = append(info.Groups, ConsumerGroup{
+			Name:            g.Name,
+			Consumers:       g.Consumers,
+			Pending:         g.Pending,
+			LastDeliveredID: g.LastDeliveredID,
+			Lag:             g.Lag,
+		})
+	}
+	return &info, nil
+}
+
+func streamEvents(messages []redis.XMessage) []StreamEvent {
+	events := make([]StreamEvent, 0, len(messages))
+	for _, m := range messages {
+		event := StreamEvent{StreamID: m.ID}
+		event.EventID, _ = m.Values["event_id"].(string)
+		event.Type, _ = m.Values["type"].(string)
+		event.Payload, _ = m.Values["payload"].(string)
+		events = append(events, event)
+	}
+	return events
+}
+
+// isRedisError reports whether err is a Redis error reply of the given kind,
+// e.g. "NOGROUP".
+func isRedisError(err error, kind string) bool {
+	var redisErr redis.Error
+	return errors.As(err, &redisErr) && strings.HasPrefix(redisErr.Error(), kind+" ")
+}
@@ -0,0 +1,347 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+const testStream = "test:events"
+
+// hookedOutbox runs beforeFind before every FindUnpublished, e.g. to let
+// time pass between two batches.
+type hookedOutbox struct {
+	repositories.OutboxRepository
+	finds      int
+	beforeFind func(find int)
+}
+
+func (o *hookedOutbox) FindUnpublished(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	o.finds++
+	if o.beforeFind != nil {
+		o.beforeFind(o.finds)
+	}
+	return o.OutboxRepository.FindUnpublished(ctx, limit)
+}
+
+type outboxFixture struct {
+	redis   *miniredis.Miniredis
+	rdb     *redis.Client
+	outbox  *hookedOutbox
+	service *services.OutboxService
+}
+
+func newOutboxFixture(t *testing.T) outboxFixture {
+	t.Helper()
+	m := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: m.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return newOutboxFixtureWith(t, m, rdb)
+}
+
+func newOutboxFixtureWith(t *testing.T, m *miniredis.Miniredis, rdb *redis.Client) outboxFixture {
+	t.Helper()
+	outbox := &hookedOutbox{OutboxRepository: repositories.NewOutboxRepository(openTestDB(t))}
+	return outboxFixture{
+		redis:   m,
+		rdb:     rdb,
+		outbox:  outbox,
+		service: services.NewOutboxService(outbox, rdb, testStream, 0),
+	}
+}
+
+// publish writes n events to the outbox and returns their IDs in order.
+func (f outboxFixture) publish(t *testing.T, n int) []string {
+	t.Helper()
+	ids := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		event := services.NewEvent(services.EventVoucherIssued, map[string]int{"voucher_id": i + 1})
+		if err := f.service.Publish(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+// streamed returns the event IDs in the stream in stream order.
+func (f outboxFixture) streamed(t *testing.T) []string {
+	t.Helper()
+	events, err := f.service.ReadStream(context.Background(), "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := []string{}
+	for _, event := range events {
+		ids = append(ids, event.EventID)
+	}
+	return ids
+}
+
+func TestRelayStreamsOutboxInOrder(t *testing.T) {
+	f := newOutboxFixture(t)
+	ctx := context.Background()
+	ids := f.publish(t, 3)
+
+	if relayed, err := f.service.Relay(ctx); err != nil || relayed != 3 {
+		t.Fatalf("Relay = %d, %v; want 3, nil", relayed, err)
+	}
+	if got := f.streamed(t); !reflect.DeepEqual(got, ids) {
+		t.Errorf("stream holds %v, want %v", got, ids)
+	}
+
+	// Relayed events are not relayed again.
+	if relayed, err := f.service.Relay(ctx); err != nil || relayed != 0 {
+		t.Errorf("second Relay = %d, %v; want 0, nil", relayed, err)
+	}
+	info, err := f.service.StreamInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Length != 3 || info.Backlog != 0 {
+		t.Errorf("StreamInfo length %d, backlog %d; want 3, 0", info.Length, info.Backlog)
+	}
+	if f.redis.Exists(services.RelayLockKey) {
+		t.Error("relay lock still held after Relay returned")
+	}
+}
+
+func TestRelayWaitsForTheRelayHoldingTheLock(t *testing.T) {
+	f := newOutboxFixture(t)
+	f.publish(t, 2)
+	f.redis.Set(services.RelayLockKey, "other-relay")
+
+	if relayed, err := f.service.Relay(context.Background()); err != nil || relayed != 0 {
+		t.Fatalf("Relay while locked = %d, %v; want 0, nil", relayed, err)
+	}
+	if got := f.streamed(t); len(got) != 0 {
+		t.Errorf("stream holds %v, want nothing", got)
+	}
+	if holder, _ := f.redis.Get(services.RelayLockKey); holder != "other-relay" {
+		t.Errorf("relay lock held by %q, want other-relay", holder)
+	}
+}
+
+func TestRelayExtendsTheLockAfterEachBatch(t *testing.T) {
+	f := newOutboxFixture(t)
+	ids := f.publish(t, services.OutboxBatchSize+1)
+
+	var ttl time.Duration
+	f.outbox.beforeFind = func(find int) {
+		switch find {
+		case 1:
+			// The first batch takes most of the lock's lifetime.
+			f.redis.FastForward(25 * time.Second)
+		case 2:
+			ttl = f.redis.TTL(services.RelayLockKey)
+		}
+	}
+
+	if relayed, err := f.service.Relay(context.Background()); err != nil || relayed != len(ids) {
+		t.Fatalf("Relay = %d, %v; want %d, nil", relayed, err, len(ids))
+	}
+	if ttl < 25*time.Second {
+		t.Errorf("relay lock expires in %s after the first batch, want it extended", ttl)
+	}
+	if got := f.streamed(t); !reflect.DeepEqual(got, ids) {
+		t.Errorf("stream holds %d events out of order, want %d in outbox order", len(got), len(ids))
+	}
+}
+
+func TestRelayStopsWhenItLosesTheLock(t *testing.T) {
+	f := newOutboxFixture(t)
+	f.publish(t, services.OutboxBatchSize+1)
+
+	f.outbox.beforeFind = func(find int) {
+		if find == 1 {
+			// The lock expires during the first batch and another relay
+			// takes it.
+			f.redis.Set(services.RelayLockKey, "other-relay")
+		}
+	}
+
+	relayed, err := f.service.Relay(context.Background())
+	if !errors.Is(err, services.ErrRelayLockLost) || relayed != services.OutboxBatchSize {
+		t.Fatalf("Relay = %d, %v; want %d, %v", relayed, err, services.OutboxBatchSize, services.ErrRelayLockLost)
+	}
+	if holder, _ := f.redis.Get(services.RelayLockKey); holder != "other-relay" {
+		t.Errorf("relay lock held by %q, want the other relay to keep it", holder)
+	}
+}
+
+func TestConsumerGroupsReadTheStreamIndependently(t *testing.T) {
+	f := newOutboxFixture(t)
+	ctx := context.Background()
+	ids := f.publish(t, 3)
+	if _, err := f.service.Relay(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating groups again leaves the existing ones alone.
+	for i := 0; i < 2; i++ {
+		if err := f.service.CreateGroups(ctx, []string{"billing", "analytics"}); err != nil {
+			t.Fatalf("CreateGroups, run %d: %v", i+1, err)
+		}
+	}
+
+	// billing fails the second event once; it is claimed again and
+	// acknowledged on the next round.
+	billing := consume(t, f.rdb, "billing", len(ids)+1, func(event services.StreamEvent, seen int) error {
+		if event.EventID == ids[1] && seen == 1 {
+			return errors.New("billing unavailable")
+		}
+		return nil
+	})
+	if want := []string{ids[0], ids[1], ids[2], ids[1]}; !reflect.DeepEqual(billing, want) {
+		t.Errorf("billing handled %v, want %v", billing, want)
+	}
+	analytics := consume(t, f.rdb, "analytics", len(ids), nil)
+	if !reflect.DeepEqual(analytics, ids) {
+		t.Errorf("analytics handled %v, want %v", analytics, ids)
+	}
+
+	info, err := f.service.StreamInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Groups) != 2 {
+		t.Fatalf("StreamInfo lists %d groups, want 2", len(info.Groups))
+	}
+	for _, group := range info.Groups {
+		if group.Pending != 0 {
+			t.Errorf("group %s has %d pending entries, want 0", group.Name, group.Pending)
+		}
+	}
+}
+
+func TestConsumerWithoutGroupFails(t *testing.T) {
+	f := newOutboxFixture(t)
+	f.publish(t, 1)
+	if _, err := f.service.Relay(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	consumer := services.NewEventConsumer(f.rdb, testStream, "missing", "worker-1")
+	err := consumer.Consume(context.Background(), func(context.Context, services.StreamEvent) error { return nil })
+	if !errors.Is(err, services.ErrConsumerGroupNotFound) {
+		t.Errorf("Consume without a group = %v, want %v", err, services.ErrConsumerGroupNotFound)
+	}
+}
+
+func TestReplayRejectsInvalidOffsets(t *testing.T) {
+	f := newOutboxFixture(t)
+	for _, offset := range []string{"", "-", "$", "1767225600000", "abc-0"} {
+		if err := f.service.Replay(context.Background(), "billing", offset); !errors.Is(err, services.ErrInvalidStreamOffset) {
+			t.Errorf("Replay(%q) = %v, want %v", offset, err, services.ErrInvalidStreamOffset)
+		}
+	}
+}
+
+// TestReplayRereadsTheStream needs a real Redis at TEST_REDIS_ADDR, since
+// miniredis does not implement XGROUP SETID. The database it selects is
+// flushed.
+func TestReplayRereadsTheStream(t *testing.T) {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("TEST_REDIS_ADDR not set")
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { rdb.Close() })
+	ctx := context.Background()
+	if err := rdb.FlushDB(ctx).Err(); err != nil {
+		t.Fatal(err)
+	}
+	f := newOutboxFixtureWith(t, nil, rdb)
+
+	ids := f.publish(t, 3)
+	if _, err := f.service.Relay(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.service.CreateGroups(ctx, []string{"billing"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := consume(t, rdb, "billing", len(ids), nil); !reflect.DeepEqual(got, ids) {
+		t.Fatalf("billing handled %v, want %v", got, ids)
+	}
+
+	events, err := f.service.ReadStream(ctx, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.service.Replay(ctx, "billing", events[0].StreamID); err != nil {
+		t.Fatal(err)
+	}
+	if got := consume(t, rdb, "billing", len(ids)-1, nil); !reflect.DeepEqual(got, ids[1:]) {
+		t.Errorf("billing handled %v after replaying from the first entry, want %v", got, ids[1:])
+	}
+	if err := f.service.Replay(ctx, "missing", "0"); !errors.Is(err, services.ErrConsumerGroupNotFound) {
+		t.Errorf("Replay of a missing group = %v, want %v", err, services.ErrConsumerGroupNotFound)
+	}
+}
+
+// consume runs a consumer of group until its handler was called at least n
+// times and the group has no pending entries left, and returns the event IDs
+// the handler was called with. fail, if set, decides whether a call fails,
+// given how often the event has been seen so far.
+func consume(t *testing.T, rdb *redis.Client, group string, n int, fail func(event services.StreamEvent, seen int) error) []string {
+	t.Helper()
+	consumer := services.NewEventConsumer(rdb, testStream, group, "worker-1")
+	consumer.MinIdle = 0
+	consumer.Block = 10 * time.Millisecond
+
+	var mu sync.Mutex
+	var handled []string
+	seen := map[string]int{}
+	handle := func(ctx context.Context, event services.StreamEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, event.EventID)
+		seen[event.EventID]++
+		if fail != nil {
+			return fail(event, seen[event.EventID])
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- consumer.Consume(ctx, handle) }()
+
+	finished := func() bool {
+		mu.Lock()
+		count := len(handled)
+		mu.Unlock()
+		if count < n {
+			return false
+		}
+		pending, err := rdb.XPending(context.Background(), testStream, group).Result()
+		return err == nil && pending.Count == 0
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !finished() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Consume for %s: %v", group, err)
+	}
+
+	ok := finished()
+	mu.Lock()
+	defer mu.Unlock()
+	if !ok {
+		t.Fatalf("consumer of %s handled %v before timing out, want %d events acknowledged", group, handled, n)
+	}
+	return handled
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+)
+
+// FlightUtilization is how full the vouchers have made one flight.
+type FlightUtilization struct {
+	FlightNumber  string
+	FlightDate    models.Date
+	Aircraft      models.Aircraft
+	Vouchers      int
+	SeatsAssigned int
+	// Capacity counts the seats vouchers can be issued for, see
+	// models.Aircraft.AssignableSeatCount.
+	Capacity       int
+	SeatsRemaining int
+	FillRate       float64
+}
+
+// AircraftTypeUtilization sums up the flights of one aircraft type.
+type AircraftTypeUtilization struct {
+	AircraftTypeKey string
+	AircraftType    string
+	Flights         int
+	Vouchers        int
+	SeatsAssigned   int
+	Capacity        int
+	FillRate        float64
+}
+
+type ReportService struct {
+	Reports repositories.ReportRepository
+}
+
+func NewReportService(reports repositories.ReportRepository) *ReportService {
+	return &ReportService{Reports: reports}
+}
+
+// Report seats assigned and remaining per flight
+func (s *ReportService) FlightUtilization(ctx context.Context, filter repositories.ReportFilter) ([]FlightUtilization, error) {
+	rows, err := s.Reports.Flights(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	flights := make([]FlightUtilization, 0, len(rows))
+	for _, row := range rows {
+		capacity := row.Aircraft.AssignableSeatCount()
+		flights = append(flights, FlightUtilization{
+			FlightNumber:   row.FlightNumber,
+			FlightDate:     row.FlightDate,
+			Aircraft:       row.Aircraft,
+			Vouchers:       row.Vouchers,
+			SeatsAssigned:  row.SeatsAssigned,
+			Capacity:       capacity,
+			SeatsRemaining: max(capacity-row.SeatsAssigned, 0),
+			FillRate:       fillRate(row.SeatsAssigned, capacity),
+		})
+	}
+	return flights, nil
+}
+
+// Report fill rates per aircraft type over the flights that have vouchers
+func (s *ReportService) AircraftTypeUtilization(ctx context.Context, filter repositories.ReportFilter) ([]AircraftTypeUtilization, error) {
+	rows, err := s.Reports.AircraftTypes(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]AircraftTypeUtilization, 0, len(rows))
+	for _, row := range rows {
+		types = append(types, AircraftTypeUtilization{
+			AircraftTypeKey: row.AircraftTypeKey,
+			AircraftType:    row.AircraftType,
+			Flights:         row.Flights,
+			Vouchers:        row.Vouchers,
+			SeatsAssigned:   row.SeatsAssigned,
+			Capacity:        row.Capacity,
+			FillRate:        fillRate(row.SeatsAssigned, row.Capacity),
+		})
+	}
+	return types, nil
+}
+
+// Report voucher usage per crew base, busiest first
+func (s *ReportService) CrewBaseUsage(ctx context.Context, filter repositories.ReportFilter) ([]repositories.CrewBaseReportRow, error) {
+	return s.Reports.CrewBases(ctx, filter)
+}
+
+// Report voucher usage per crew member, busiest first
+func (s *ReportService) CrewUsage(ctx context.Context, filter repositories.ReportFilter) ([]repositories.CrewReportRow, error) {
+	return s.Reports.Crew(ctx, filter)
+}
+
+// fillRate returns assigned/capacity rounded to four decimals, or 0 for an
+// aircraft without assignable seats.
+func fillRate(assigned, capacity int) float64 {
+	if capacity <= 0 {
+		return 0
+	}
+	return math.Round(float64(assigned)/float64(capacity)*10000) / 10000
+}
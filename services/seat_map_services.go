@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+)
+
+// Seat statuses on a seat map.
+const (
+	SeatFree     = "free"
+	SeatAssigned = "assigned"
+	SeatBlocked  = "blocked"
+)
+
+// SeatMap is the cabin of a flight with the status of every seat.
+type SeatMap struct {
+	FlightNumber string
+	FlightDate   models.Date
+	Aircraft     models.Aircraft
+	// Groups holds the seat letters between aisles, e.g. ["ABC", "DEF"].
+	Groups []string
+	// Rows holds the seats of each row, front to back.
+	Rows [][]SeatMapSeat
+}
+
+// SeatMapSeat is one seat on a seat map.
+type SeatMapSeat struct {
+	Seat     string
+	Row      int
+	Letter   string
+	Position string
+	Status   string
+	// VoucherID is the voucher the seat is assigned to, zero when not assigned.
+	VoucherID uint
+}
+
+// Count returns how many seats have the status.
+func (m *SeatMap) Count(status string) int {
+	count := 0
+	for _, row := range m.Rows {
+		for _, seat := range row {
+			if seat.Status == status {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Build the seat map of a flight. The aircraft is the one given by its type
+// key, or else the one the flight's vouchers were issued for.
+func (s *VoucherService) SeatMap(ctx context.Context, flightNumber string, flightDate models.Date, aircraftTypeKey string) (*SeatMap, error) {
+	vouchers, err := s.Vouchers.FindByFlight(ctx, flightNumber, flightDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load voucher data: %w", err)
+	}
+
+	aircraft, err := s.seatMapAircraft(ctx, vouchers, aircraftTypeKey)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned := map[string]uint{}
+	for i := range vouchers {
+		for _, seat := range vouchers[i].Seats() {
+			assigned[seat] = vouchers[i].ID
+		}
+	}
+
+	positions := aircraft.SeatPositions()
+	seatMap := &SeatMap{
+		FlightNumber: flightNumber,
+		FlightDate:   flightDate,
+		Aircraft:     *aircraft,
+		Groups:       aircraft.SeatGroups(),
+		Rows:         make([][]SeatMapSeat, 0, aircraft.NumRows),
+	}
+	for row := 1; row <= aircraft.NumRows; row++ {
+		seats := make([]SeatMapSeat, 0, len(aircraft.SeatLetters()))
+		for _, letter := range aircraft.SeatLetters() {
+			seat := SeatMapSeat{
+				Seat:     fmt.Sprintf("%d%c", row, letter),
+				Row:      row,
+				Letter:   string(letter),
+				Position: positions[letter],
+				Status:   SeatFree,
+			}
+			if id, ok := assigned[seat.Seat]; ok {
+				seat.Status = SeatAssigned
+				seat.VoucherID = id
+			} else if aircraft.IsBlocked(seat.Seat) {
+				seat.Status = SeatBlocked
+			}
+			seats = append(seats, seat)
+		}
+		seatMap.Rows = append(seatMap.Rows, seats)
+	}
+	return seatMap, nil
+}
+
+func (s *VoucherService) seatMapAircraft(ctx context.Context, vouchers []models.Voucher, aircraftTypeKey string) (*models.Aircraft, error) {
+	if aircraftTypeKey != "" {
+		aircraft, err := s.Aircraft.FindByKey(ctx, aircraftTypeKey)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrAircraftNotFound
+		}
+		return aircraft, err
+	}
+
+	for _, voucher := range vouchers {
+		if voucher.AircraftID == nil {
+			continue
+		}
+		aircraft, err := s.Aircraft.FindByID(ctx, *voucher.AircraftID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrAircraftNotFound
+		}
+		return aircraft, err
+	}
+	return nil, fmt.Errorf("%w, pass aircraft_type_key to see its seat map", ErrFlightNotFound)
+}
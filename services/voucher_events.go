@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"VSA_GOGIN_BE/models"
+)
+
+// VoucherEvent is the data of voucher events. Crew names are left out, so
+// receivers get no more personal data than they need.
+type VoucherEvent struct {
+	VoucherID        uint     `json:"voucher_id"`
+	CrewID           string   `json:"crew_id"`
+	CrewBase         string   `json:"crew_base,omitempty"`
+	FlightNumber     string   `json:"flight_number"`
+	FlightDate       string   `json:"flight_date"`
+	DepartureAirport string   `json:"departure_airport,omitempty"`
+	AircraftType     string   `json:"aircraft_type"`
+	AircraftTypeKey  string   `json:"aircraft_type_key"`
+	Seats            []string `json:"seats"`
+	// PreviousSeats are the seats a regenerated voucher gave up.
+	PreviousSeats []string `json:"previous_seats,omitempty"`
+}
+
+func newVoucherEvent(voucher *models.Voucher) VoucherEvent {
+	return VoucherEvent{
+		VoucherID:        voucher.ID,
+		CrewID:           voucher.CrewID,
+		CrewBase:         voucher.CrewBase,
+		FlightNumber:     voucher.FlightNumber,
+		FlightDate:       voucher.FlightDate.String(),
+		DepartureAirport: voucher.DepartureAirport,
+		AircraftType:     voucher.AircraftType,
+		AircraftTypeKey:  voucher.AircraftTypeKey,
+		Seats:            voucher.Seats(),
+	}
+}
+
+// publish announces a change to voucher. It must run inside the transaction
+// making the change.
+func (s *VoucherService) publish(ctx context.Context, eventType string, event VoucherEvent) error {
+	if s.Events == nil {
+		return nil
+	}
+	if err := s.Events.Publish(ctx, NewEvent(eventType, event)); err != nil {
+		return fmt.Errorf("failed to publish %s: %w", eventType, err)
+	}
+	return nil
+}
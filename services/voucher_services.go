@@ -8,121 +8,574 @@ import (
 	"math/rand"
 	"time"
 
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/metrics"
 	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
 
 	"github.com/redis/go-redis/v9"
-	"gorm.io/gorm"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var tracer = otel.Tracer("VSA_GOGIN_BE/services")
+
+const (
+	// seatCachePrefix starts the Redis keys of cached free seat lists.
+	seatCachePrefix = "voucher_seat_cache:"
+	seatCacheTTL    = time.Hour
+	// seatsPerVoucher is how many seats a voucher offers the crew member.
+	seatsPerVoucher = 3
 )
 
 type VoucherService struct {
-	DB  *gorm.DB
-	RDB *redis.Client
+	Vouchers repositories.VoucherRepository
+	Aircraft repositories.AircraftRepository
+	Audit    *AuditService
+	RDB      *redis.Client
+	Tx       repositories.Transactor
+	// Events is told about issued, regenerated, cancelled and restored
+	// vouchers. It may be nil.
+	Events EventPublisher
+}
+
+func NewVoucherService(vouchers repositories.VoucherRepository, aircraft repositories.AircraftRepository, audit *AuditService, rdb *redis.Client, tx repositories.Transactor, events EventPublisher) *VoucherService {
+	return &VoucherService{
+		Vouchers: vouchers,
+		Aircraft: aircraft,
+		Audit:    audit,
+		RDB:      rdb,
+		Tx:       tx,
+		Events:   events,
+	}
+}
+
+// List all vouchers, optionally including soft-deleted ones
+func (s *VoucherService) ListVouchers(ctx context.Context, includeDeleted bool) ([]models.Voucher, error) {
+	return s.Vouchers.List(ctx, includeDeleted)
+}
+
+// Soft-delete (cancel) a voucher by ID
+func (s *VoucherService) DeleteVoucher(ctx context.Context, id uint) error {
+	voucher, err := s.Vouchers.FindByID(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrVoucherNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.Vouchers.Delete(ctx, voucher); err != nil {
+			return err
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionDelete,
+			Entity:   AuditEntityVoucher,
+			EntityID: voucher.ID,
+			Before:   voucher,
+		}); err != nil {
+			return err
+		}
+		return s.publish(ctx, EventVoucherCancelled, newVoucherEvent(voucher))
+	})
+	if err != nil {
+		return err
+	}
+	s.forgetSeats(ctx, voucher)
+	return nil
+}
+
+// Restore a soft-deleted voucher by ID. Restoring is refused when the crew
+// member was issued a new voucher for the flight in the meantime, when any of
+// its seats now belong to another voucher, or when its aircraft is deleted.
+func (s *VoucherService) RestoreVoucher(ctx context.Context, id uint) (*models.Voucher, error) {
+	voucher, err := s.Vouchers.FindByIDWithDeleted(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrVoucherNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !voucher.DeletedAt.Valid {
+		return voucher, nil
+	}
+
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		exists, err := s.CheckVoucherExists(ctx, voucher)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w for crew id: %s on this flight and date", ErrVoucherExists, voucher.CrewID)
+		}
+
+		if voucher.AircraftID != nil {
+			_, err := s.Aircraft.FindByID(ctx, *voucher.AircraftID)
+			if errors.Is(err, repositories.ErrNotFound) {
+				return ErrAircraftNotFound
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		taken, err := s.takenSeats(ctx, voucher.FlightNumber, voucher.FlightDate)
+		if err != nil {
+			return err
+		}
+		for _, seat := range voucher.Seats() {
+			if taken[seat] {
+				return fmt.Errorf("%w: %s", ErrSeatsReassigned, seat)
+			}
+		}
+
+		err = s.Vouchers.Restore(ctx, voucher)
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return fmt.Errorf("%w for crew id: %s on this flight and date", ErrVoucherExists, voucher.CrewID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionRestore,
+			Entity:   AuditEntityVoucher,
+			EntityID: voucher.ID,
+			After:    voucher,
+		}); err != nil {
+			return err
+		}
+		return s.publish(ctx, EventVoucherRestored, newVoucherEvent(voucher))
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.forgetSeats(ctx, voucher)
+	return voucher, nil
+}
+
+// Archive vouchers for flights older than the retention period
+func (s *VoucherService) ArchiveVouchers(ctx context.Context, retention time.Duration) (int64, error) {
+	cutoff := models.DateOf(time.Now().Add(-retention))
+	return s.Vouchers.ArchiveFlownBefore(ctx, cutoff, 500)
 }
 
-// Check if voucher exists
-func (s *VoucherService) CheckVoucherExists(voucher *models.Voucher) (bool, error) {
-	var vouchers []models.Voucher
-	if err := s.DB.
-		Where("flight_number = ?", voucher.FlightNumber).
-		Where("flight_date = ?", voucher.FlightDate).
-		Where("crew_id = ?", voucher.CrewID).
-		Where("aircraft_type_key = ?", voucher.AircraftTypeKey).
-		Find(&vouchers).Error; err != nil {
-		return false, errors.New("database error while checking voucher")
+// Check if the crew member already has a voucher for the flight
+func (s *VoucherService) CheckVoucherExists(ctx context.Context, voucher *models.Voucher) (bool, error) {
+	existing, err := s.FindVoucher(ctx, voucher)
+	return existing != nil, err
+}
+
+// Find the voucher already generated for the crew member on the flight, or
+// nil when there is none. Like the unique index, it ignores the aircraft:
+// a crew member holds at most one voucher per flight whatever the aircraft.
+func (s *VoucherService) FindVoucher(ctx context.Context, voucher *models.Voucher) (*models.Voucher, error) {
+	existing, err := s.Vouchers.FindByCrewOnFlight(ctx, voucher.CrewID, voucher.FlightNumber, voucher.FlightDate)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error while checking voucher: %w", err)
+	}
+	return existing, nil
+}
+
+// Find the vouchers for several crew members and flights at once, matching
+// like FindVoucher. The result lines up with lookups and holds nil where no
+// voucher exists.
+func (s *VoucherService) FindVouchers(ctx context.Context, lookups []models.Voucher) ([]*models.Voucher, error) {
+	vouchers, err := s.Vouchers.FindExistingMany(ctx, lookups)
+	if err != nil {
+		return nil, fmt.Errorf("database error while checking vouchers: %w", err)
 	}
 
-	return len(vouchers) > 0, nil
+	results := make([]*models.Voucher, len(lookups))
+	for i, l := range lookups {
+		for j := range vouchers {
+			v := &vouchers[j]
+			if v.CrewID == l.CrewID && v.FlightNumber == l.FlightNumber && v.FlightDate == l.FlightDate {
+				results[i] = v
+				break
+			}
+		}
+	}
+	return results, nil
 }
 
-// Generate voucher seats
-func (s *VoucherService) GenerateVoucherSeats(voucher *models.Voucher) ([]string, error) {
-	// 1️⃣ Check if already exists
-	exists, err := s.CheckVoucherExists(voucher)
+// FreeSeatsByFlight reports the seats left on every flight departing today
+// or later that has vouchers, today being taken in the default time zone.
+func (s *VoucherService) FreeSeatsByFlight(ctx context.Context) ([]metrics.FlightSeats, error) {
+	loc, err := models.AirportLocation("")
 	if err != nil {
 		return nil, err
 	}
-	if exists {
-		return nil, fmt.Errorf("Voucher already generated for crew id: %s on this flight and date", voucher.CrewID)
+	counts, err := s.Vouchers.CountSeatsByFlight(ctx, models.DateOf(time.Now().In(loc)))
+	if err != nil {
+		return nil, err
+	}
+
+	flights := make([]metrics.FlightSeats, 0, len(counts))
+	for _, c := range counts {
+		flights = append(flights, metrics.FlightSeats{
+			FlightNumber: c.FlightNumber,
+			FlightDate:   c.FlightDate.String(),
+			AircraftType: c.Aircraft.AircraftType,
+			Free:         max(c.Aircraft.AssignableSeatCount()-c.SeatsAssigned, 0),
+		})
 	}
+	return flights, nil
+}
 
-	// 2️⃣ Try cache
-	cacheKey := "voucher_seat_cache:" + voucher.FlightNumber + ":" + voucher.FlightDate.String() + ":" + voucher.AircraftType
-	var allSeats []string
+// Generate voucher seats. The existence check, seat selection and insert run
+// in one transaction, so concurrent requests can neither issue a crew member
+// two vouchers for a flight nor assign the same seat twice.
+func (s *VoucherService) GenerateVoucherSeats(ctx context.Context, voucher *models.Voucher) ([]string, error) {
+	aircraft, err := s.Aircraft.FindByKey(ctx, voucher.AircraftTypeKey)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrAircraftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	cacheSeat, err := s.RDB.Get(context.Background(), cacheKey).Result()
+	// 1️⃣ Try cache
+	cacheKey := seatCacheKey(voucher.FlightNumber, voucher.FlightDate, voucher.AircraftTypeKey)
+	var cachedSeats []string
+
+	cacheSeat, err := s.RDB.Get(ctx, cacheKey).Result()
 	if err == nil {
-		json.Unmarshal([]byte(cacheSeat), &allSeats)
+		json.Unmarshal([]byte(cacheSeat), &cachedSeats)
+	}
+	if len(cachedSeats) > 0 {
+		metrics.CacheRequests.WithLabelValues("voucher_seats", metrics.CacheHit).Inc()
+	} else {
+		metrics.CacheRequests.WithLabelValues("voucher_seats", metrics.CacheMiss).Inc()
 	}
 
-	var aircraft models.Aircraft
-	if err := s.DB.
-		Where("aircraft_type_key = ?", voucher.AircraftTypeKey).
-		First(&aircraft).Error; err != nil {
-		return nil, errors.New("aircraft not found")
+	var created models.Voucher
+	var selected, remaining []string
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// The transaction may be retried, so start from the request each time.
+		created = *voucher
+
+		// 2️⃣ Check if already exists
+		exists, err := s.CheckVoucherExists(ctx, &created)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w for crew id: %s on this flight and date", ErrVoucherExists, created.CrewID)
+		}
+
+		// 3️⃣ Drop seats assigned since the list was cached, and generate the
+		// list when the cache is empty or has run dry
+		taken, err := s.takenSeats(ctx, created.FlightNumber, created.FlightDate)
+		if err != nil {
+			return err
+		}
+		// Seats blocked or removed from the layout after the list was
+		// cached are never handed out.
+		for _, seat := range aircraft.BlockedSeatList() {
+			taken[seat] = true
+		}
+		allSeats := withoutSeats(onAircraft(cachedSeats, aircraft), taken)
+		if len(allSeats) == 0 {
+			allSeats = withoutSeats(seatLayout(aircraft), taken)
+		}
+
+		// 4️⃣ Randomly pick seats
+		considered := append([]string(nil), allSeats...)
+		selected, remaining = pickSeats(ctx, allSeats, seatsPerVoucher)
+		if len(selected) == 0 {
+			return ErrNoSeatsAvailable
+		}
+
+		created.AircraftID = &aircraft.ID
+		created.AircraftType = aircraft.AircraftType
+		assignSeats(&created, selected)
+
+		// 5️⃣ Save to DB
+		// The unique index on crew, flight and date catches a voucher
+		// committed by a request racing past the check above.
+		err = s.Vouchers.Create(ctx, &created)
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return fmt.Errorf("%w for crew id: %s on this flight and date", ErrVoucherExists, created.CrewID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save voucher with assigned seats: %w", err)
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:          AuditActionGenerate,
+			Entity:          AuditEntityVoucher,
+			EntityID:        created.ID,
+			After:           &created,
+			SeatsConsidered: considered,
+			SeatsChosen:     selected,
+		}); err != nil {
+			return err
+		}
+		return s.publish(ctx, EventVoucherIssued, newVoucherEvent(&created))
+	})
+	if err != nil {
+		return nil, err
 	}
+	*voucher = created
+	voucher.Aircraft = aircraft
+
+	// 6️⃣ Cache the seats still free, so the next request does not pick the
+	// ones just assigned
+	seatsJSON, _ := json.Marshal(remaining)
+	s.RDB.Set(ctx, cacheKey, seatsJSON, seatCacheTTL)
+
+	metrics.VouchersIssued.WithLabelValues(aircraft.AircraftType).Inc()
+	logging.For("services").InfoContext(ctx, "voucher issued",
+		"voucher_id", voucher.ID,
+		"crew_id", voucher.CrewID,
+		"crew_name", voucher.CrewName,
+		"flight_number", voucher.FlightNumber,
+		"flight_date", voucher.FlightDate.String(),
+		"seats", selected,
+	)
+	return selected, nil
+}
 
-	// 3️⃣ Generate if cache empty
-	if len(allSeats) == 0 {
-		var vouchers []models.Voucher
-		if err := s.DB.
-			Where("flight_number = ?", voucher.FlightNumber).
-			Where("flight_date = ?", voucher.FlightDate).
-			Find(&vouchers).Error; err != nil {
-			return nil, errors.New("failed to load voucher data")
+// Regenerate the seats of a voucher by ID. Its seats are given up and up to
+// three are picked again from the seats no other voucher holds, so the new
+// seats may include old ones.
+func (s *VoucherService) RegenerateVoucherSeats(ctx context.Context, id uint) (*models.Voucher, error) {
+	var regenerated models.Voucher
+	var aircraft *models.Aircraft
+	var previous, selected []string
+	err := s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		voucher, err := s.Vouchers.FindByID(ctx, id)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrVoucherNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if voucher.AircraftID == nil {
+			return ErrAircraftNotFound
+		}
+		aircraft, err = s.Aircraft.FindByID(ctx, *voucher.AircraftID)
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrAircraftNotFound
+		}
+		if err != nil {
+			return err
 		}
 
-		skipList := map[string]bool{}
-		for _, v := range vouchers {
-			for _, seat := range []string{v.Seat1, v.Seat2, v.Seat3} {
-				if seat != "" {
-					skipList[seat] = true
-				}
-			}
+		taken, err := s.takenSeats(ctx, voucher.FlightNumber, voucher.FlightDate)
+		if err != nil {
+			return err
 		}
+		previous = voucher.Seats()
+		for _, seat := range previous {
+			delete(taken, seat)
+		}
+		allSeats := withoutSeats(seatLayout(aircraft), taken)
 
-		rows := aircraft.NumRows
-		sections := aircraft.SeatsPerRow
-		for i := 1; i <= rows; i++ {
-			for _, section := range sections {
-				seat := fmt.Sprintf("%d%c", i, section)
-				if !skipList[seat] {
-					allSeats = append(allSeats, seat)
-				}
-			}
+		considered := append([]string(nil), allSeats...)
+		selected, _ = pickSeats(ctx, allSeats, seatsPerVoucher)
+		if len(selected) == 0 {
+			return ErrNoSeatsAvailable
 		}
 
-		// cache new seats
-		seatsJSON, _ := json.Marshal(allSeats)
-		s.RDB.Set(context.Background(), cacheKey, seatsJSON, time.Hour)
+		regenerated = *voucher
+		assignSeats(&regenerated, selected)
+		if err := s.Vouchers.UpdateSeats(ctx, &regenerated); err != nil {
+			return fmt.Errorf("failed to save regenerated seats: %w", err)
+		}
+
+		if err := s.Audit.Record(ctx, AuditEntry{
+			Action:          AuditActionRegenerate,
+			Entity:          AuditEntityVoucher,
+			EntityID:        regenerated.ID,
+			Before:          voucher,
+			After:           &regenerated,
+			SeatsConsidered: considered,
+			SeatsChosen:     selected,
+		}); err != nil {
+			return err
+		}
+		event := newVoucherEvent(&regenerated)
+		event.PreviousSeats = previous
+		return s.publish(ctx, EventVoucherRegenerated, event)
+	})
+	if err != nil {
+		return nil, err
 	}
+	regenerated.Aircraft = aircraft
+	s.forgetSeats(ctx, &regenerated)
+
+	logging.For("services").InfoContext(ctx, "voucher seats regenerated",
+		"voucher_id", regenerated.ID,
+		"crew_id", regenerated.CrewID,
+		"flight_number", regenerated.FlightNumber,
+		"flight_date", regenerated.FlightDate.String(),
+		"previous_seats", previous,
+		"seats", selected,
+	)
+	return &regenerated, nil
+}
+
+// pickSeats shuffles seats and splits off the first n of them, or all of
+// them when fewer are left.
+func pickSeats(ctx context.Context, seats []string, n int) (selected, remaining []string) {
+	_, span := tracer.Start(ctx, "select seats")
+	defer span.End()
+	span.SetAttributes(attribute.Int("seats.available", len(seats)))
 
-	// 4️⃣ Randomly pick seats
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	r.Shuffle(len(allSeats), func(i, j int) {
-		allSeats[i], allSeats[j] = allSeats[j], allSeats[i]
+	r.Shuffle(len(seats), func(i, j int) {
+		seats[i], seats[j] = seats[j], seats[i]
 	})
-	numSeats := 3
-	if len(allSeats) < numSeats {
-		numSeats = len(allSeats)
+	n = min(n, len(seats))
+	return seats[:n], seats[n:]
+}
+
+// assignSeats puts seats on the voucher, clearing the seat columns left over.
+func assignSeats(voucher *models.Voucher, seats []string) {
+	columns := []*string{&voucher.Seat1, &voucher.Seat2, &voucher.Seat3}
+	for i, column := range columns {
+		*column = ""
+		if i < len(seats) {
+			*column = seats[i]
+		}
 	}
-	if numSeats == 0 {
-		return nil, errors.New("no seats available for this flight")
+}
+
+// freeSeats lists the seats of aircraft not assigned to any voucher on the
+// flight, row by row.
+func (s *VoucherService) freeSeats(ctx context.Context, flightNumber string, flightDate models.Date, aircraft *models.Aircraft) ([]string, error) {
+	taken, err := s.takenSeats(ctx, flightNumber, flightDate)
+	if err != nil {
+		return nil, err
 	}
+	return withoutSeats(seatLayout(aircraft), taken), nil
+}
 
-	selected := allSeats[:numSeats]
-	voucher.AircraftType = aircraft.AircraftType
-	voucher.Seat1 = selected[0]
-	if len(selected) > 1 {
-		voucher.Seat2 = selected[1]
+// takenSeats returns the seats assigned by vouchers on the flight.
+func (s *VoucherService) takenSeats(ctx context.Context, flightNumber string, flightDate models.Date) (map[string]bool, error) {
+	vouchers, err := s.Vouchers.FindByFlight(ctx, flightNumber, flightDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load voucher data: %w", err)
 	}
-	if len(selected) > 2 {
-		voucher.Seat3 = selected[2]
+
+	taken := map[string]bool{}
+	for i := range vouchers {
+		for _, seat := range vouchers[i].Seats() {
+			taken[seat] = true
+		}
 	}
+	return taken, nil
+}
 
-	// 5️⃣ Save to DB
-	if err := s.DB.Save(voucher).Error; err != nil {
-		return nil, errors.New("failed to save voucher with assigned seats")
+// seatLayout lists every seat of the aircraft that can be assigned, row by
+// row. Blocked seats are left out.
+func seatLayout(aircraft *models.Aircraft) []string {
+	var seats []string
+	rows := aircraft.NumRows
+	sections := aircraft.SeatLetters()
+	for i := 1; i <= rows; i++ {
+		for _, section := range sections {
+			seat := fmt.Sprintf("%d%c", i, section)
+			if !aircraft.IsBlocked(seat) {
+				seats = append(seats, seat)
+			}
+		}
 	}
+	return seats
+}
 
-	return selected, nil
+func withoutSeats(seats []string, taken map[string]bool) []string {
+	free := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		if !taken[seat] {
+			free = append(free, seat)
+		}
+	}
+	return free
+}
+
+// onAircraft returns the seats that exist on the aircraft's current layout.
+func onAircraft(seats []string, aircraft *models.Aircraft) []string {
+	existing := make([]string, 0, len(seats))
+	for _, seat := range seats {
+		if aircraft.HasSeat(seat) {
+			existing = append(existing, seat)
+		}
+	}
+	return existing
+}
+
+// forgetSeats drops the cached free seats of the voucher's flight after its
+// seats were released or taken back.
+func (s *VoucherService) forgetSeats(ctx context.Context, voucher *models.Voucher) {
+	s.RDB.Del(ctx, seatCacheKey(voucher.FlightNumber, voucher.FlightDate, voucher.AircraftTypeKey))
+}
+
+func seatCacheKey(flightNumber string, flightDate models.Date, aircraftTypeKey string) string {
+	return seatCachePrefix + flightNumber + ":" + flightDate.String() + ":" + aircraftTypeKey
+}
+
+// forgetAircraftSeats drops the cached free seats of every flight of the
+// aircraft, e.g. after its seat layout changed. rdb may be nil.
+func forgetAircraftSeats(ctx context.Context, rdb *redis.Client, aircraftTypeKey string) error {
+	if rdb == nil {
+		return nil
+	}
+	iter := rdb.Scan(ctx, 0, seatCachePrefix+"*:"+aircraftTypeKey, 100).Iterator()
+	for iter.Next(ctx) {
+		if err := rdb.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
+
+// Remove every cached free seat list, returning how many were removed
+func (s *VoucherService) FlushSeatCache(ctx context.Context) (int64, error) {
+	var removed int64
+	iter := s.RDB.Scan(ctx, 0, seatCachePrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		n, err := s.RDB.Del(ctx, iter.Val()).Result()
+		if err != nil {
+			return removed, err
+		}
+		removed += n
+	}
+	return removed, iter.Err()
+}
+
+// Cache the free seats of every flight departing today or later that has
+// vouchers, returning how many flights were cached
+func (s *VoucherService) WarmSeatCache(ctx context.Context) (int, error) {
+	loc, err := models.AirportLocation("")
+	if err != nil {
+		return 0, err
+	}
+	flights, err := s.Vouchers.CountSeatsByFlight(ctx, models.DateOf(time.Now().In(loc)))
+	if err != nil {
+		return 0, err
+	}
+
+	for i, flight := range flights {
+		seats, err := s.freeSeats(ctx, flight.FlightNumber, flight.FlightDate, &flight.Aircraft)
+		if err != nil {
+			return i, err
+		}
+		seatsJSON, err := json.Marshal(seats)
+		if err != nil {
+			return i, err
+		}
+		key := seatCacheKey(flight.FlightNumber, flight.FlightDate, flight.Aircraft.AircraftTypeKey)
+		if err := s.RDB.Set(ctx, key, seatsJSON, seatCacheTTL).Err(); err != nil {
+			return i, err
+		}
+	}
+	return len(flights), nil
 }
@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories/memory"
+	"VSA_GOGIN_BE/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestVoucherLookupsAgreeWithGeneration(t *testing.T) {
+	ctx := context.Background()
+	vouchers := memory.NewVoucherRepository()
+	aircraft := memory.NewAircraftRepository(vouchers)
+	audit := services.NewAuditService(memory.NewAuditLogRepository())
+	service := services.NewVoucherService(vouchers, aircraft, audit, nil, memory.NewTransactor(), nil)
+
+	flightDate := models.NewDate(2025, 12, 1)
+	issued := models.Voucher{CrewID: "A1", FlightNumber: "GA123", FlightDate: flightDate, AircraftTypeKey: "atr_72", Seat1: "1A"}
+	if err := vouchers.Create(ctx, &issued); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		lookup models.Voucher
+		want   bool
+	}{
+		{"same aircraft", models.Voucher{CrewID: "A1", FlightNumber: "GA123", FlightDate: flightDate, AircraftTypeKey: "atr_72"}, true},
+		{"other aircraft", models.Voucher{CrewID: "A1", FlightNumber: "GA123", FlightDate: flightDate, AircraftTypeKey: "boeing_737"}, true},
+		{"no aircraft", models.Voucher{CrewID: "A1", FlightNumber: "GA123", FlightDate: flightDate}, true},
+		{"other crew member", models.Voucher{CrewID: "B2", FlightNumber: "GA123", FlightDate: flightDate, AircraftTypeKey: "atr_72"}, false},
+		{"other day", models.Voucher{CrewID: "A1", FlightNumber: "GA123", FlightDate: flightDate.AddDays(1), AircraftTypeKey: "atr_72"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exists, err := service.CheckVoucherExists(ctx, &tt.lookup)
+			if err != nil || exists != tt.want {
+				t.Errorf("CheckVoucherExists = %v, %v, want %v", exists, err, tt.want)
+			}
+			found, err := service.FindVoucher(ctx, &tt.lookup)
+			if err != nil || (found != nil) != tt.want {
+				t.Errorf("FindVoucher = %v, %v, want found %v", found, err, tt.want)
+			}
+			many, err := service.FindVouchers(ctx, []models.Voucher{tt.lookup})
+			if err != nil || (many[0] != nil) != tt.want {
+				t.Errorf("FindVouchers = %v, %v, want found %v", many, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerationSkipsSeatsRemovedFromTheLayout(t *testing.T) {
+	ctx := context.Background()
+	m := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: m.Addr()})
+	vouchers := memory.NewVoucherRepository()
+	aircraft := memory.NewAircraftRepository(vouchers)
+	audit := services.NewAuditService(memory.NewAuditLogRepository())
+	tx := memory.NewTransactor()
+	aircraftService := services.NewAircraftService(aircraft, vouchers, audit, rdb, services.DeletePolicyBlock, tx, nil)
+	voucherService := services.NewVoucherService(vouchers, aircraft, audit, rdb, tx, nil)
+
+	a320 := models.Aircraft{AircraftType: "Airbus A320", NumRows: 30, SeatsPerRow: "ABC-DEF"}
+	if err := aircraftService.CreateAircraft(ctx, &a320); err != nil {
+		t.Fatal(err)
+	}
+	flightDate := models.NewDate(2025, 12, 1)
+	generate := func(crewID string) []string {
+		t.Helper()
+		voucher := models.Voucher{CrewID: crewID, FlightNumber: "GA123", FlightDate: flightDate, AircraftTypeKey: a320.AircraftTypeKey}
+		seats, err := voucherService.GenerateVoucherSeats(ctx, &voucher)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return seats
+	}
+	generate("A1")
+	cacheKey := "voucher_seat_cache:GA123:" + flightDate.String() + ":" + a320.AircraftTypeKey
+	if !m.Exists(cacheKey) {
+		t.Fatalf("seat cache %s was not warmed", cacheKey)
+	}
+
+	a320.NumRows = 2
+	a320.BlockedSeats = "1A"
+	if err := aircraftService.UpdateAircraft(ctx, &a320); err != nil {
+		t.Fatal(err)
+	}
+	if m.Exists(cacheKey) {
+		t.Errorf("seat cache %s survived the layout change", cacheKey)
+	}
+
+	// A list cached before the change, e.g. by a request racing the update,
+	// still holds seats that are gone now.
+	m.Set(cacheKey, `["1A","30F","29C","2B"]`)
+	for _, crewID := range []string{"B2", "C3"} {
+		for _, seat := range generate(crewID) {
+			if !a320.HasSeat(seat) || seat == "1A" {
+				t.Errorf("crew %s got seat %s, which the layout no longer offers", crewID, seat)
+			}
+		}
+	}
+}
@@ -0,0 +1,439 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"VSA_GOGIN_BE/logging"
+	"VSA_GOGIN_BE/metrics"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+)
+
+// WebhookEvents lists every event type subscriptions can ask for.
+var WebhookEvents = []string{EventVoucherIssued, EventVoucherRegenerated, EventVoucherCancelled, EventVoucherRestored}
+
+// Headers sent with every webhook delivery
+const (
+	WebhookSignatureHeader = "X-VSA-Signature"
+	WebhookEventHeader     = "X-VSA-Event"
+	WebhookEventIDHeader   = "X-VSA-Event-ID"
+	WebhookDeliveryHeader  = "X-VSA-Delivery"
+)
+
+const (
+	// webhookBatchSize is how many due deliveries are claimed at a time.
+	webhookBatchSize = 50
+	// webhookResponseLimit is how much of a response body is read before
+	// the connection is released.
+	webhookResponseLimit = 64 << 10
+)
+
+type WebhookService struct {
+	Webhooks repositories.WebhookRepository
+	Audit    *AuditService
+	Tx       repositories.Transactor
+	Client   *http.Client
+	// Retry spaces out the attempts of a failing delivery. Deliveries still
+	// failing after Retry.MaxAttempts are dead-lettered.
+	Retry repositories.RetryPolicy
+	// AllowInsecureURLs lets subscriptions use plain http and loopback or
+	// private hosts, e.g. a receiver on a developer's machine. Otherwise
+	// only https URLs are accepted and deliveries never connect to a
+	// non-public address, whatever the host name resolves to.
+	AllowInsecureURLs bool
+}
+
+func NewWebhookService(webhooks repositories.WebhookRepository, audit *AuditService, tx repositories.Transactor, timeout time.Duration, retry repositories.RetryPolicy, allowInsecureURLs bool) *WebhookService {
+	if retry.MaxAttempts < 1 {
+		retry.MaxAttempts = 1
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowInsecureURLs {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: dialPublicOnly}
+		transport.DialContext = dialer.DialContext
+	}
+	return &WebhookService{
+		Webhooks: webhooks,
+		Audit:    audit,
+		Tx:       tx,
+		Client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect would turn the POST into a GET; report it instead.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		Retry:             retry,
+		AllowInsecureURLs: allowInsecureURLs,
+	}
+}
+
+// List all webhook subscriptions
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]models.WebhookSubscription, error) {
+	return s.Webhooks.ListSubscriptions(ctx)
+}
+
+// Get webhook subscription by ID
+func (s *WebhookService) GetSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	subscription, err := s.Webhooks.FindSubscription(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrWebhookNotFound
+	}
+	return subscription, err
+}
+
+// Create a webhook subscription with a fresh signing secret. The secret is
+// only ever returned here, in subscription.Secret.
+func (s *WebhookService) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if err := s.validateSubscription(subscription); err != nil {
+		return err
+	}
+	subscription.Secret = "whsec_" + randomHex(24)
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.Webhooks.CreateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+
+		return s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionCreate,
+			Entity:   AuditEntityWebhook,
+			EntityID: subscription.ID,
+			After:    subscription,
+		})
+	})
+}
+
+// Update an existing subscription loaded with GetSubscription. Deliveries
+// already queued still go to the URL they were queued for.
+func (s *WebhookService) UpdateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if err := s.validateSubscription(subscription); err != nil {
+		return err
+	}
+
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.GetSubscription(ctx, subscription.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.Webhooks.UpdateSubscription(ctx, subscription); err != nil {
+			return err
+		}
+
+		return s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionUpdate,
+			Entity:   AuditEntityWebhook,
+			EntityID: subscription.ID,
+			Before:   before,
+			After:    subscription,
+		})
+	})
+}
+
+// Soft-delete a webhook subscription by ID
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uint) error {
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		subscription, err := s.GetSubscription(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.Webhooks.DeleteSubscription(ctx, subscription); err != nil {
+			return err
+		}
+
+		return s.Audit.Record(ctx, AuditEntry{
+			Action:   AuditActionDelete,
+			Entity:   AuditEntityWebhook,
+			EntityID: subscription.ID,
+			Before:   subscription,
+		})
+	})
+}
+
+// List webhook deliveries matching the filter, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, filter repositories.WebhookDeliveryFilter) ([]models.WebhookDelivery, error) {
+	return s.Webhooks.ListDeliveries(ctx, filter)
+}
+
+// Get webhook delivery by ID, with its attempt log
+func (s *WebhookService) GetDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	delivery, err := s.Webhooks.FindDelivery(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrDeliveryNotFound
+	}
+	return delivery, err
+}
+
+// Queue a dead-lettered or delivered event for another round of attempts,
+// e.g. once the receiver is fixed. The attempt log is kept.
+func (s *WebhookService) RetryDelivery(ctx context.Context, id uint) (*models.WebhookDelivery, error) {
+	delivery, err := s.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == models.DeliveryPending {
+		return nil, ErrDeliveryPending
+	}
+
+	delivery.Status = models.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC()
+	delivery.DeliveredAt = nil
+	if err := s.Webhooks.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Publish queues a delivery of the event for every active subscription to
+// its type. Called inside a transaction, the deliveries are committed or
+// rolled back together with the change they announce.
+func (s *WebhookService) Publish(ctx context.Context, event Event) error {
+	subscriptions, err := s.Webhooks.SubscriptionsFor(ctx, event.Type)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			Event:          event.Type,
+			Payload:        string(payload),
+			Status:         models.DeliveryPending,
+			NextAttemptAt:  event.CreatedAt,
+		})
+	}
+	return s.Webhooks.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverDue attempts every delivery that is due, returning how many were
+// attempted
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		deliveries, err := s.Webhooks.ClaimDue(ctx, time.Now().UTC(), s.lease(), webhookBatchSize)
+		if err != nil {
+			return attempted, err
+		}
+		for i := range deliveries {
+			if err := s.deliver(ctx, &deliveries[i]); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+		if len(deliveries) < webhookBatchSize {
+			return attempted, nil
+		}
+	}
+}
+
+// deliver POSTs the delivery once and records the outcome. Attempts cut off
+// by ctx are not recorded; the delivery is picked up again once its lease
+// runs out.
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	subscription := delivery.Subscription
+	attempt := models.WebhookAttempt{DeliveryID: delivery.ID, Attempt: delivery.Attempts + 1}
+
+	var err error
+	final := false
+	if subscription == nil || !subscription.Active {
+		err = errors.New("subscription deleted or disabled")
+		final = true
+	} else {
+		start := time.Now()
+		attempt.StatusCode, err = s.post(ctx, subscription, delivery)
+		attempt.DurationMs = time.Since(start).Milliseconds()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = ""
+	outcome := metrics.WebhookSucceeded
+	switch {
+	case err == nil:
+		now := time.Now().UTC()
+		delivery.Status = models.DeliverySucceeded
+		delivery.DeliveredAt = &now
+	case final || delivery.Attempts >= s.Retry.MaxAttempts:
+		attempt.Error = err.Error()
+		delivery.LastError = err.Error()
+		delivery.Status = models.DeliveryDeadLetter
+		outcome = metrics.WebhookDeadLetter
+	default:
+		attempt.Error = err.Error()
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = time.Now().UTC().Add(s.backoff(delivery.Attempts))
+		outcome = metrics.WebhookFailed
+	}
+
+	if err := s.Webhooks.SaveAttempt(ctx, delivery, &attempt); err != nil {
+		return fmt.Errorf("failed to record webhook delivery %d: %w", delivery.ID, err)
+	}
+	metrics.WebhookDeliveries.WithLabelValues(delivery.Event, outcome).Inc()
+
+	log := logging.For("webhooks")
+	args := []interface{}{
+		"delivery_id", delivery.ID,
+		"subscription_id", delivery.SubscriptionID,
+		"event", delivery.Event,
+		"event_id", delivery.EventID,
+		"attempt", delivery.Attempts,
+		"status_code", attempt.StatusCode,
+	}
+	switch outcome {
+	case metrics.WebhookSucceeded:
+		log.InfoContext(ctx, "webhook delivered", args...)
+	case metrics.WebhookDeadLetter:
+		log.ErrorContext(ctx, "webhook dead-lettered", append(args, "error", err)...)
+	default:
+		log.WarnContext(ctx, "webhook delivery failed", append(args, "error", err, "next_attempt_at", delivery.NextAttemptAt)...)
+	}
+	return nil
+}
+
+// post sends the delivery's payload to the subscription, returning the
+// response status code. Responses outside 2xx are errors.
+func (s *WebhookService) post(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "VSA-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookEventIDHeader, delivery.EventID)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(subscription.Secret, time.Now(), body))
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, webhookResponseLimit))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// SignWebhook returns the X-VSA-Signature header for body sent at timestamp:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed with secret>".
+// Receivers recompute the HMAC and should reject old timestamps, so that a
+// captured request cannot be replayed later.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns how long to wait after the given number of failed
+// attempts: Retry.BaseDelay doubling every attempt, with jitter, up to
+// Retry.MaxDelay.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := s.Retry.BaseDelay
+	for i := 1; i < attempts && delay < s.Retry.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, s.Retry.MaxDelay)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// lease is how long a claimed delivery is held before another worker may
+// attempt it, comfortably longer than one attempt can take.
+func (s *WebhookService) lease() time.Duration {
+	if s.Client.Timeout <= 0 {
+		return time.Minute
+	}
+	return 2 * s.Client.Timeout
+}
+
+func (s *WebhookService) validateSubscription(subscription *models.WebhookSubscription) error {
+	u, err := url.Parse(subscription.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if !s.AllowInsecureURLs {
+		if u.Scheme != "https" {
+			return fmt.Errorf("%w: url must use https", ErrInvalidWebhook)
+		}
+		if !isPublicHost(u.Hostname()) {
+			return fmt.Errorf("%w: url must not point to a loopback or private address", ErrInvalidWebhook)
+		}
+	}
+	events := subscription.EventList()
+	if len(events) == 0 {
+		return fmt.Errorf("%w: events must not be empty", ErrInvalidWebhook)
+	}
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return fmt.Errorf("%w: unknown event %s", ErrInvalidWebhook, event)
+		}
+	}
+	return nil
+}
+
+// isPublicHost reports whether host may be public: a host name other than
+// localhost, or a public IP address. Names resolving to private addresses
+// are caught by dialPublicOnly when a delivery connects.
+func isPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return isPublicAddr(addr)
+	}
+	return true
+}
+
+// dialPublicOnly refuses connections to non-public addresses. It runs after
+// name resolution, so it also covers host names that resolve to, or are
+// rebound to, internal addresses.
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("webhook receiver address %s is not public", addrPort.Addr())
+	}
+	return nil
+}
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast()
+}
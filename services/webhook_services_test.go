@@ -0,0 +1,304 @@
+package services_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"VSA_GOGIN_BE/database"
+	"VSA_GOGIN_BE/models"
+	"VSA_GOGIN_BE/repositories"
+	"VSA_GOGIN_BE/services"
+
+	"gorm.io/gorm"
+)
+
+// receivedWebhook is one POST seen by a webhookReceiver.
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver answers deliveries with the next status in statuses,
+// repeating the last one once they run out.
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	received []receivedWebhook
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	t.Helper()
+	r := &webhookReceiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		status := r.statuses[min(len(r.received), len(r.statuses)-1)]
+		r.received = append(r.received, receivedWebhook{header: req.Header.Clone(), body: body})
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *webhookReceiver) requests() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.received...)
+}
+
+type webhookFixture struct {
+	service      *services.WebhookService
+	webhooks     repositories.WebhookRepository
+	subscription models.WebhookSubscription
+}
+
+// openTestDB opens a migrated SQLite database that lives as long as t.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.Open(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newWebhookService(t *testing.T, retry repositories.RetryPolicy, allowInsecureURLs bool) (*services.WebhookService, repositories.WebhookRepository) {
+	t.Helper()
+	db := openTestDB(t)
+	webhooks := repositories.NewWebhookRepository(db)
+	audit := services.NewAuditService(repositories.NewAuditLogRepository(db))
+	tx := repositories.NewTransactor(db, repositories.DefaultRetryPolicy)
+	return services.NewWebhookService(webhooks, audit, tx, 2*time.Second, retry, allowInsecureURLs), webhooks
+}
+
+// newWebhookFixture subscribes receiver to voucher.issued and queues one
+// delivery for it.
+func newWebhookFixture(t *testing.T, receiver *webhookReceiver, retry repositories.RetryPolicy) webhookFixture {
+	t.Helper()
+	service, webhooks := newWebhookService(t, retry, true)
+	ctx := context.Background()
+	f := webhookFixture{
+		service:  service,
+		webhooks: webhooks,
+		subscription: models.WebhookSubscription{
+			URL:    receiver.URL + "/hooks",
+			Events: services.EventVoucherIssued,
+			Active: true,
+		},
+	}
+	if err := service.CreateSubscription(ctx, &f.subscription); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Publish(ctx, services.NewEvent(services.EventVoucherIssued, map[string]string{"crew_id": "C001"})); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// deliver runs one dispatcher pass and returns the delivery afterwards.
+func (f webhookFixture) deliver(t *testing.T) *models.WebhookDelivery {
+	t.Helper()
+	if _, err := f.service.DeliverDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	delivery, err := f.service.GetDelivery(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return delivery
+}
+
+// makeDue moves the delivery's next attempt into the past, as if its backoff
+// had passed.
+func (f webhookFixture) makeDue(t *testing.T, delivery *models.WebhookDelivery) {
+	t.Helper()
+	delivery.NextAttemptAt = time.Now().UTC().Add(-time.Second)
+	if err := f.webhooks.UpdateDelivery(context.Background(), delivery); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusNoContent)
+	f := newWebhookFixture(t, receiver, repositories.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour})
+
+	delivery := f.deliver(t)
+	requests := receiver.requests()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	got := requests[0]
+	if event := got.header.Get(services.WebhookEventHeader); event != services.EventVoucherIssued {
+		t.Errorf("%s = %q, want %q", services.WebhookEventHeader, event, services.EventVoucherIssued)
+	}
+	if id := got.header.Get(services.WebhookEventIDHeader); id != delivery.EventID {
+		t.Errorf("%s = %q, want %q", services.WebhookEventIDHeader, id, delivery.EventID)
+	}
+	if string(got.body) != delivery.Payload {
+		t.Errorf("body = %s, want the delivery payload %s", got.body, delivery.Payload)
+	}
+
+	// Verify the signature the way a receiver would, without SignWebhook.
+	signature := got.header.Get(services.WebhookSignatureHeader)
+	parts := strings.Split(signature, ",")
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "t=") || !strings.HasPrefix(parts[1], "v1=") {
+		t.Fatalf("%s = %q, want t=<unix>,v1=<hmac>", services.WebhookSignatureHeader, signature)
+	}
+	timestamp := strings.TrimPrefix(parts[0], "t=")
+	if sent, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(sent, 0)) > time.Minute {
+		t.Errorf("signature timestamp %q is not the time of sending", timestamp)
+	}
+	mac := hmac.New(sha256.New, []byte(f.subscription.Secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(got.body)
+	if want := hex.EncodeToString(mac.Sum(nil)); !hmac.Equal([]byte(strings.TrimPrefix(parts[1], "v1=")), []byte(want)) {
+		t.Errorf("signature v1 = %s, want %s", strings.TrimPrefix(parts[1], "v1="), want)
+	}
+}
+
+func TestWebhookDeliveryRetriesWithBackoffUntilSuccess(t *testing.T) {
+	const baseDelay = time.Minute
+	receiver := newWebhookReceiver(t, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+	f := newWebhookFixture(t, receiver, repositories.RetryPolicy{MaxAttempts: 5, BaseDelay: baseDelay, MaxDelay: time.Hour})
+
+	// The delay doubles with every failure; jitter keeps it within the upper
+	// half of that.
+	for attempt, status := range []int{http.StatusServiceUnavailable, http.StatusBadGateway} {
+		before := time.Now().UTC()
+		delivery := f.deliver(t)
+		delay := baseDelay << attempt
+		if delivery.Status != models.DeliveryPending || delivery.Attempts != attempt+1 || delivery.LastStatusCode != status {
+			t.Fatalf("after attempt %d: status %s, attempts %d, last status code %d; want pending, %d, %d",
+				attempt+1, delivery.Status, delivery.Attempts, delivery.LastStatusCode, attempt+1, status)
+		}
+		if next := delivery.NextAttemptAt; next.Before(before.Add(delay/2)) || next.After(time.Now().UTC().Add(delay)) {
+			t.Errorf("after attempt %d: next attempt in %s, want between %s and %s", attempt+1, next.Sub(before), delay/2, delay)
+		}
+
+		// Not due yet, so another pass leaves it alone.
+		if n, err := f.service.DeliverDue(context.Background()); err != nil || n != 0 {
+			t.Fatalf("DeliverDue before the backoff passed = %d, %v; want 0, nil", n, err)
+		}
+		f.makeDue(t, delivery)
+	}
+
+	delivery := f.deliver(t)
+	if delivery.Status != models.DeliverySucceeded || delivery.Attempts != 3 || delivery.DeliveredAt == nil {
+		t.Fatalf("after a 200: status %s, attempts %d, delivered at %v; want succeeded, 3, set", delivery.Status, delivery.Attempts, delivery.DeliveredAt)
+	}
+
+	// A delivered event is not sent again.
+	f.makeDue(t, delivery)
+	f.deliver(t)
+	if n := len(receiver.requests()); n != 3 {
+		t.Errorf("receiver got %d requests, want 3", n)
+	}
+}
+
+func TestWebhookDeliveryIsDeadLetteredAfterMaxAttempts(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError)
+	f := newWebhookFixture(t, receiver, repositories.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour})
+
+	delivery := f.deliver(t)
+	f.makeDue(t, delivery)
+	delivery = f.deliver(t)
+	if delivery.Status != models.DeliveryDeadLetter || delivery.Attempts != 2 {
+		t.Fatalf("after 2 failed attempts: status %s, attempts %d; want %s, 2", delivery.Status, delivery.Attempts, models.DeliveryDeadLetter)
+	}
+
+	// Dead letters stay put until retried by hand.
+	f.makeDue(t, delivery)
+	if n, err := f.service.DeliverDue(context.Background()); err != nil || n != 0 {
+		t.Errorf("DeliverDue with only a dead letter = %d, %v; want 0, nil", n, err)
+	}
+	if n := len(receiver.requests()); n != 2 {
+		t.Errorf("receiver got %d requests, want 2", n)
+	}
+
+	if len(delivery.AttemptLog) != 2 {
+		t.Fatalf("attempt log has %d rows, want 2", len(delivery.AttemptLog))
+	}
+	for i, attempt := range delivery.AttemptLog {
+		if attempt.Attempt != i+1 || attempt.StatusCode != http.StatusInternalServerError || attempt.Error == "" {
+			t.Errorf("attempt log row %d = %+v, want attempt %d with status 500 and an error", i, attempt, i+1)
+		}
+	}
+}
+
+func TestWebhookSubscriptionURLs(t *testing.T) {
+	tests := []struct {
+		url           string
+		allowInsecure bool
+		valid         bool
+	}{
+		{"https://hooks.example.com/vsa", false, true},
+		{"http://hooks.example.com/vsa", false, false},
+		{"https://localhost/vsa", false, false},
+		{"https://127.0.0.1:8443/vsa", false, false},
+		{"https://10.1.2.3/vsa", false, false},
+		{"https://192.168.0.10/vsa", false, false},
+		{"https://169.254.169.254/latest/meta-data", false, false},
+		{"https://[::1]/vsa", false, false},
+		{"https://[fd00::1]/vsa", false, false},
+		{"ftp://hooks.example.com/vsa", false, false},
+		{"http://127.0.0.1:9000/vsa", true, true},
+		{"ftp://hooks.example.com/vsa", true, false},
+	}
+	for _, tt := range tests {
+		service, _ := newWebhookService(t, repositories.RetryPolicy{}, tt.allowInsecure)
+		subscription := models.WebhookSubscription{URL: tt.url, Events: services.EventVoucherIssued, Active: true}
+		err := service.CreateSubscription(context.Background(), &subscription)
+		if tt.valid && err != nil {
+			t.Errorf("CreateSubscription(%s, allow insecure %v) = %v, want nil", tt.url, tt.allowInsecure, err)
+		}
+		if !tt.valid && !errors.Is(err, services.ErrInvalidWebhook) {
+			t.Errorf("CreateSubscription(%s, allow insecure %v) = %v, want %v", tt.url, tt.allowInsecure, err, services.ErrInvalidWebhook)
+		}
+	}
+}
+
+func TestWebhookDeliveryRefusesPrivateAddresses(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	service, webhooks := newWebhookService(t, repositories.RetryPolicy{MaxAttempts: 1}, false)
+	ctx := context.Background()
+
+	// A host name may resolve to a private address after it was validated,
+	// so the subscription is stored as is to reach the dialer.
+	subscription := models.WebhookSubscription{URL: receiver.URL, Events: services.EventVoucherIssued, Secret: "whsec_test", Active: true}
+	if err := webhooks.CreateSubscription(ctx, &subscription); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Publish(ctx, services.NewEvent(services.EventVoucherIssued, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.DeliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	delivery, err := service.GetDelivery(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != models.DeliveryDeadLetter || !strings.Contains(delivery.LastError, "not public") {
+		t.Errorf("delivery to %s: status %s, error %q; want dead-lettered as not public", receiver.URL, delivery.Status, delivery.LastError)
+	}
+	if n := len(receiver.requests()); n != 0 {
+		t.Errorf("receiver got %d requests, want 0", n)
+	}
+}
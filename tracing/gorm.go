@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// parentContextKey keeps the statement's context from before its span was
+// started, so it can be restored once the span ends.
+const parentContextKey = "tracing:parent_context"
+
+// GormPlugin starts a span for every statement, as a child of the span in
+// the context passed to db.WithContext.
+type GormPlugin struct {
+	tracer trace.Tracer
+}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{tracer: otel.Tracer("VSA_GOGIN_BE/gorm")}
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", p.start("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", p.end),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", p.start("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", p.end),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", p.start("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", p.end),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", p.start("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", p.end),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", p.start("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", p.end),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", p.start("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", p.end),
+	)
+}
+
+func (p *GormPlugin) start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		ctx, _ := p.tracer.Start(parent, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemSqlite, attribute.String("db.operation.name", operation)),
+		)
+		db.InstanceSet(parentContextKey, parent)
+		db.Statement.Context = ctx
+	}
+}
+
+func (p *GormPlugin) end(db *gorm.DB) {
+	parent, ok := db.InstanceGet(parentContextKey)
+	if !ok {
+		return
+	}
+
+	span := trace.SpanFromContext(db.Statement.Context)
+	span.SetAttributes(
+		attribute.String("db.collection.name", db.Statement.Table),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+	span.End()
+
+	db.Statement.Context = parent.(context.Context)
+}
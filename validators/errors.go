@@ -0,0 +1,99 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors converts a binding error into one FieldError per failing field.
+// It returns nil when the error is not tied to specific fields, e.g. malformed JSON.
+func FieldErrors(err error) []FieldError {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		fields := make([]FieldError, 0, len(validationErrors))
+		for _, fe := range validationErrors {
+			fields = append(fields, FieldError{
+				Field:   fieldPath(fe),
+				Message: message(fe),
+			})
+		}
+		return fields
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		return []FieldError{{
+			Field:   typeError.Field,
+			Message: fmt.Sprintf("must be of type %s", typeError.Type.String()),
+		}}
+	}
+
+	return nil
+}
+
+// fieldPath returns the JSON path of the field without the root struct name,
+// e.g. "items[0].crew_id".
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+// unit names what min and max count for the field's kind.
+func unit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	default:
+		return "characters"
+	}
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	case "min":
+		return fmt.Sprintf("must contain at least %s %s", fe.Param(), unit(fe))
+	case "max":
+		return fmt.Sprintf("must contain at most %s %s", fe.Param(), unit(fe))
+	case "datetime":
+		if fe.Param() == "2006-01-02" {
+			return "must be a date (YYYY-MM-DD)"
+		}
+		return fmt.Sprintf("must be formatted as %s", fe.Param())
+	case "seat_letters":
+		return "must contain unique uppercase seat letters between A and K, with - marking aisles"
+	case "flight_number":
+		return "must be an IATA flight number, e.g. GA123"
+	case "flight_date":
+		return "must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"
+	case "http_url":
+		return "must be an http or https URL"
+	case "airport":
+		return "must be a supported IATA airport code, e.g. CGK"
+	default:
+		return fmt.Sprintf("failed on %s validation", fe.Tag())
+	}
+}
@@ -0,0 +1,91 @@
+package validators
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"VSA_GOGIN_BE/models"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// flightNumberPattern matches an IATA flight designator: a two-character
+// airline code (at least one letter) followed by 1-4 digits and an optional
+// operational suffix, e.g. "GA123", "ID001" or "3K512A".
+var flightNumberPattern = regexp.MustCompile(`^([A-Z]{2}|[A-Z][0-9]|[0-9][A-Z])[0-9]{1,4}[A-Z]?$`)
+
+// Register attaches the custom validators to gin's binding engine and makes
+// validation errors report JSON field names instead of Go field names.
+func Register() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "uri"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	v.RegisterValidation("seat_letters", validateSeatLetters)
+	v.RegisterValidation("flight_number", validateFlightNumber)
+	v.RegisterValidation("flight_date", validateFlightDate)
+	v.RegisterValidation("airport", validateAirport)
+}
+
+func validateSeatLetters(fl validator.FieldLevel) bool {
+	return ValidSeatLetters(fl.Field().String())
+}
+
+// ValidSeatLetters reports whether letters is a non-empty string of unique
+// uppercase seat letters between A and K, optionally split into blocks by
+// aisle markers, e.g. "ABCDEF" or "ABC-DEF".
+func ValidSeatLetters(letters string) bool {
+	if letters == "" {
+		return false
+	}
+
+	seen := map[rune]bool{}
+	for _, block := range strings.Split(letters, string(models.AisleMarker)) {
+		if block == "" {
+			return false
+		}
+		for _, letter := range block {
+			if letter < 'A' || letter > 'K' || seen[letter] {
+				return false
+			}
+			seen[letter] = true
+		}
+	}
+	return true
+}
+
+func validateFlightNumber(fl validator.FieldLevel) bool {
+	return flightNumberPattern.MatchString(fl.Field().String())
+}
+
+// validateFlightDate accepts a "YYYY-MM-DD" date or an RFC 3339 timestamp.
+func validateFlightDate(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if _, err := models.ParseDate(value); err == nil {
+		return true
+	}
+	_, err := time.Parse(time.RFC3339, value)
+	return err == nil
+}
+
+// validateAirport accepts IATA airport codes whose time zone is known.
+func validateAirport(fl validator.FieldLevel) bool {
+	return models.KnownAirport(fl.Field().String())
+}